@@ -2,6 +2,10 @@ resource "nextdns_profile" "this" {
   name = "terraform-provider-nextdns"
 }
 
+resource "nextdns_profile" "other" {
+  name = "terraform-provider-nextdns-entries"
+}
+
 resource "nextdns_denylist" "this" {
   profile_id = nextdns_profile.this.id
 
@@ -14,6 +18,31 @@ resource "nextdns_denylist" "this" {
     id     = "bing.com"
     active = false
   }
+
+  # Removed from the deny list once expired.
+  domain {
+    id         = "incident.example.com"
+    active     = true
+    expires_at = "2030-01-01T00:00:00Z"
+  }
+
+  # The domains of hosts files and adblock lists are merged with the domain blocks.
+  source {
+    format  = "adblock"
+    content = <<-EOT
+      ! Blocked trackers
+      ||tracker.example.com^
+      @@||cdn.tracker.example.com^
+    EOT
+  }
+}
+
+# Manages a single entry of the deny list, without touching the other entries.
+# It should not be used together with nextdns_denylist on the same profile.
+resource "nextdns_denylist_domain" "this" {
+  profile_id = nextdns_profile.other.id
+  domain     = "example.com"
+  active     = true
 }
 
 resource "nextdns_allowlist" "this" {
@@ -30,6 +59,14 @@ resource "nextdns_allowlist" "this" {
   }
 }
 
+# Manages a single entry of the allow list, without touching the other entries.
+# It should not be used together with nextdns_allowlist on the same profile.
+resource "nextdns_allowlist_domain" "this" {
+  profile_id = nextdns_profile.other.id
+  domain     = "example.org"
+  active     = true
+}
+
 resource "nextdns_parental_control" "this" {
   profile_id = nextdns_profile.this.id
 
@@ -70,21 +107,18 @@ resource "nextdns_parental_control" "this" {
   recreation {
     timezone = "America/New_York"
 
-    monday {
-      start = "07:02:00"
-      end   = "23:00:00"
+    schedule {
+      days  = ["monday", "tuesday", "wednesday", "thursday"]
+      start = "16:00:00"
+      end   = "18:00:00"
     }
-    saturday {
-      start = "20:22:00"
-      end   = "23:33:00"
-    }
-    friday {
-      start = "12:00:00"
-      end   = "15:35:00"
-    }
-    sunday {
-      start = "01:00:00"
-      end   = "23:00:00"
+
+    # Friday and Saturday evenings, until 1 AM on the next day.
+    schedule {
+      days             = ["friday", "saturday"]
+      start            = "20:00:00"
+      end              = "01:00:00"
+      crosses_midnight = true
     }
   }
 }
@@ -168,6 +202,17 @@ resource "nextdns_settings" "this" {
   web3 = true
 }
 
+# Purges the stored logs on creation and whenever the location or retention of the logs change.
+resource "nextdns_logs_purge" "this" {
+  profile_id = nextdns_profile.this.id
+
+  triggers = {
+    location  = nextdns_settings.this.logs[0].location
+    retention = nextdns_settings.this.logs[0].retention
+  }
+}
+
+# Owns every rewrite of the profile, deleting the ones it does not declare.
 resource "nextdns_rewrite" "this" {
   profile_id = nextdns_profile.this.id
 
@@ -182,6 +227,15 @@ resource "nextdns_rewrite" "this" {
   }
 }
 
+# Manages a single rewrite, without touching the other rewrites of the profile.
+# It cannot be used together with nextdns_rewrite or the rewrite block of nextdns_profile on the same profile,
+# as they delete the rewrite on every apply.
+resource "nextdns_rewrite_record" "this" {
+  profile_id = nextdns_profile.other.id
+  domain     = "router.example.com"
+  address    = "192.168.1.1"
+}
+
 data "nextdns_setup_endpoint" "this" {
   profile_id = nextdns_profile.this.id
 }
@@ -190,6 +244,67 @@ data "nextdns_setup_linkedip" "this" {
   profile_id = nextdns_profile.this.id
 }
 
+data "nextdns_analytics_status" "this" {
+  profile_id = nextdns_profile.this.id
+  from       = "-7d"
+}
+
+data "nextdns_analytics_domains" "blocked" {
+  profile_id = nextdns_profile.this.id
+  from       = "-7d"
+  status     = "blocked"
+  root       = true
+  limit      = 10
+}
+
+data "nextdns_logs" "blocked" {
+  profile_id = nextdns_profile.this.id
+  from       = "-1h"
+  status     = "blocked"
+  search     = "google"
+  limit      = 50
+}
+
+data "nextdns_profiles" "this" {
+  name_regex = "^terraform-provider-"
+}
+
+data "nextdns_profile" "this" {
+  name = "terraform-provider-nextdns"
+
+  depends_on = [nextdns_profile.this]
+}
+
+# Evaluates offline how a domain is resolved by the given rules.
+data "nextdns_domain_verdict" "this" {
+  domain = "cdn.example.com"
+
+  denylist {
+    id = "example.com"
+  }
+
+  allowlist {
+    id = "cdn.example.com"
+  }
+}
+
+# Lists the services and categories known by the provider, which validates the ones of the parental control.
+# Set allow_unknown_ids to true on nextdns_parental_control to use the ones missing from the catalog.
+data "nextdns_parental_control_catalog" "this" {}
+
+# Tells whether the recreation time of the parental control is active, and when its windows start and end.
+data "nextdns_recreation_status" "this" {
+  profile_id = nextdns_parental_control.this.profile_id
+}
+
+# Warns when the planned allow list overlaps the deny list, or the deny list the rewrites.
+# Only the active domains which did not expire are checked, as the profile resolves with them.
+data "nextdns_profile_policy_check" "this" {
+  allowlist = [for d in nextdns_allowlist.this.domain : d.id if d.active && !contains(nextdns_allowlist.this.expired_domains, d.id)]
+  denylist  = [for d in nextdns_denylist.this.domain : d.id if d.active && !contains(nextdns_denylist.this.expired_domains, d.id)]
+  rewrites  = [for r in nextdns_rewrite.this.rewrite : r.domain]
+}
+
 terraform {
   required_providers {
     nextdns = {
@@ -218,3 +333,43 @@ output "servers" {
   description = "The DNS servers available for the profile"
   value = data.nextdns_setup_linkedip.this.servers
 }
+
+# Manages the whole configuration of the profile in a single resource.
+# The nested blocks should not be used together with the standalone resources on the same profile.
+resource "nextdns_profile" "full" {
+  name = "terraform-provider-nextdns-full"
+
+  security {
+    threat_intelligence_feeds = true
+    ai_threat_detection       = true
+    google_safe_browsing      = true
+    crypto_jacking            = true
+    dns_rebinding             = true
+    idn_homographs            = true
+    typo_squatting            = true
+    dga                       = true
+    nrd                       = false
+    ddns                      = false
+    parking                   = true
+    csam                      = true
+    tlds                      = ["ru", "cn"]
+  }
+
+  privacy {
+    disguised_trackers = true
+    allow_affiliate    = false
+    blocklists         = ["nextdns-recommended"]
+  }
+
+  denylist {
+    domain {
+      id     = "google.com"
+      active = true
+    }
+  }
+
+  rewrite {
+    domain  = "example.com"
+    address = "192.168.0.1"
+  }
+}
@@ -0,0 +1,669 @@
+// Package fakeapi provides an in-memory fake of the NextDNS API, used to test the provider without an account.
+package fakeapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Server is an in-memory fake of the NextDNS API served over HTTP.
+// The profiles are kept as decoded JSON objects, so every nested object and list of a profile
+// can be read and written through its API path the same way as the NextDNS API does.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	nextID    int
+	profiles  map[string]map[string]interface{}
+	logs      map[string][]map[string]interface{}
+	analytics map[string]map[string][]map[string]interface{}
+	rejected  map[string]bool
+	requests  []string
+}
+
+// New starts a new fake NextDNS API, which must be closed by the caller.
+func New() *Server {
+	s := &Server{
+		profiles:  make(map[string]map[string]interface{}),
+		logs:      make(map[string][]map[string]interface{}),
+		analytics: make(map[string]map[string][]map[string]interface{}),
+		rejected:  make(map[string]bool),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+// Profile returns a copy of the profile as it is stored by the fake API.
+func (s *Server) Profile(id string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.profiles[id]
+	if !ok {
+		return nil, false
+	}
+
+	return deepCopy(p).(map[string]interface{}), true
+}
+
+// ProfileIDs returns the IDs of every profile, sorted.
+func (s *Server) ProfileIDs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.profileIDs()
+}
+
+// UpdateProfile changes the profile as stored by the fake API, as if it was changed outside of the provider.
+func (s *Server) UpdateProfile(id string, fn func(profile map[string]interface{})) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.profiles[id]
+	if ok {
+		fn(p)
+	}
+
+	return ok
+}
+
+// CreateProfile creates a profile with the default configuration, merged with the given fields, and returns its ID.
+func (s *Server) CreateProfile(fields map[string]interface{}) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.createProfile(fields)
+}
+
+// SetLogs replaces the query logs of the profile, most recent entries first.
+func (s *Server) SetLogs(profileID string, entries []map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.logs[profileID] = entries
+}
+
+// Logs returns the query logs of the profile.
+func (s *Server) Logs(profileID string) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.logs[profileID]
+}
+
+// SetAnalytics replaces the items returned by an analytics endpoint (e.g. status or domains) of the profile.
+func (s *Server) SetAnalytics(profileID string, endpoint string, items []map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.analytics[profileID] == nil {
+		s.analytics[profileID] = make(map[string][]map[string]interface{})
+	}
+	s.analytics[profileID][endpoint] = items
+}
+
+// Reject makes the fake API reject the requests sending the value as the id or name of an entry,
+// with an error pointing to the value within the request body as the NextDNS API does for invalid values.
+func (s *Server) Reject(value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rejected[value] = true
+}
+
+// Requests returns the requests received by the fake API, formatted as "METHOD /path".
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+
+	if len(r.Header.Get("X-Api-Key")) == 0 {
+		writeError(w, http.StatusForbidden, "authRequired", "")
+		return
+	}
+
+	var body interface{}
+	if r.Body != nil && r.Method != http.MethodGet {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+			writeError(w, http.StatusBadRequest, "invalidJson", "")
+			return
+		}
+	}
+
+	if pointer, ok := s.findRejected(body, ""); ok {
+		writeErrorPointer(w, http.StatusBadRequest, "invalid", pointer)
+		return
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if segments[0] != "profiles" {
+		writeError(w, http.StatusNotFound, "notFound", "")
+		return
+	}
+
+	if len(segments) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			s.listProfiles(w, r)
+		case http.MethodPost:
+			fields, _ := body.(map[string]interface{})
+			writeData(w, map[string]interface{}{"id": s.createProfile(fields)})
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "methodNotAllowed", "")
+		}
+		return
+	}
+
+	profileID := segments[1]
+	profile, ok := s.profiles[profileID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "notFound", "")
+		return
+	}
+
+	path := segments[2:]
+	if len(path) > 0 {
+		switch path[0] {
+		case "analytics":
+			s.handleAnalytics(w, r, profileID, path[1:])
+			return
+		case "logs":
+			s.handleLogs(w, r, profileID)
+			return
+		}
+	}
+
+	s.handleObject(w, r, profileID, profile, path, body)
+}
+
+// handleObject reads or writes the object of the profile at the given path.
+func (s *Server) handleObject(w http.ResponseWriter, r *http.Request, profileID string, profile map[string]interface{}, path []string, body interface{}) {
+	if len(path) == 0 && r.Method == http.MethodDelete {
+		delete(s.profiles, profileID)
+		delete(s.logs, profileID)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	var parent interface{}
+	var key string
+	var target interface{} = profile
+
+	for _, seg := range path {
+		next, k, ok := child(target, seg)
+		if !ok {
+			writeError(w, http.StatusNotFound, "notFound", "")
+			return
+		}
+		parent, key, target = target, k, next
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, target)
+
+	case http.MethodPatch:
+		obj, ok := target.(map[string]interface{})
+		patch, valid := body.(map[string]interface{})
+		if !ok || !valid {
+			writeError(w, http.StatusBadRequest, "invalid", "")
+			return
+		}
+		merge(obj, patch)
+		if _, ok := obj["content"]; ok && len(path) > 0 && path[0] == "rewrites" {
+			obj["type"] = rewriteType(fmt.Sprint(obj["content"]))
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case http.MethodPut:
+		if parent == nil {
+			writeError(w, http.StatusMethodNotAllowed, "methodNotAllowed", "")
+			return
+		}
+		if items, ok := body.([]interface{}); ok {
+			for _, item := range items {
+				if obj, ok := item.(map[string]interface{}); ok {
+					applyDefaults(path[len(path)-1], obj)
+				}
+			}
+		}
+		set(parent, key, body)
+		w.WriteHeader(http.StatusNoContent)
+
+	case http.MethodPost:
+		items, ok := target.([]interface{})
+		item, valid := body.(map[string]interface{})
+		if !ok || !valid || parent == nil {
+			writeError(w, http.StatusBadRequest, "invalid", "")
+			return
+		}
+
+		list := path[len(path)-1]
+		if list == "rewrites" {
+			s.nextID++
+			item["id"] = fmt.Sprintf("%x", s.nextID)
+			item["type"] = rewriteType(fmt.Sprint(item["content"]))
+		}
+
+		id, _ := item["id"].(string)
+		if len(id) == 0 {
+			writeErrorParameter(w, http.StatusBadRequest, "invalid", "id")
+			return
+		}
+		for _, e := range items {
+			if e.(map[string]interface{})["id"] == id {
+				// The NextDNS API reports duplicated entries with an HTTP 200.
+				writeErrorParameter(w, http.StatusOK, "duplicate", "id")
+				return
+			}
+		}
+
+		applyDefaults(list, item)
+		set(parent, key, append(items, item))
+		writeData(w, item)
+
+	case http.MethodDelete:
+		items, ok := parent.([]interface{})
+		if !ok {
+			writeError(w, http.StatusMethodNotAllowed, "methodNotAllowed", "")
+			return
+		}
+		var kept []interface{}
+		for _, e := range items {
+			if e.(map[string]interface{})["id"] != key {
+				kept = append(kept, e)
+			}
+		}
+		if kept == nil {
+			kept = []interface{}{}
+		}
+		setPath(profile, path[:len(path)-1], kept)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "methodNotAllowed", "")
+	}
+}
+
+// findRejected returns the JSON pointer of the first rejected value of the request body.
+func (s *Server) findRejected(body interface{}, pointer string) (string, bool) {
+	switch v := body.(type) {
+	case map[string]interface{}:
+		for _, k := range []string{"id", "name"} {
+			if id, ok := v[k].(string); ok && s.rejected[id] {
+				return pointer + "/" + k, true
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if p, ok := s.findRejected(v[k], pointer+"/"+k); ok {
+				return p, true
+			}
+		}
+	case []interface{}:
+		for i, e := range v {
+			if p, ok := s.findRejected(e, pointer+"/"+strconv.Itoa(i)); ok {
+				return p, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func (s *Server) profileIDs() []string {
+	ids := make([]string, 0, len(s.profiles))
+	for id := range s.profiles {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+func (s *Server) listProfiles(w http.ResponseWriter, r *http.Request) {
+	ids := s.profileIDs()
+
+	items := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, map[string]interface{}{
+			"id":          id,
+			"fingerprint": "fp" + id,
+			"role":        "owner",
+			"name":        s.profiles[id]["name"],
+		})
+	}
+
+	writePage(w, r, items)
+}
+
+func (s *Server) handleAnalytics(w http.ResponseWriter, r *http.Request, profileID string, path []string) {
+	if r.Method != http.MethodGet || len(path) != 1 {
+		writeError(w, http.StatusNotFound, "notFound", "")
+		return
+	}
+
+	items := s.analytics[profileID][path[0]]
+	if items == nil {
+		items = []map[string]interface{}{}
+	}
+
+	writePage(w, r, items)
+}
+
+func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request, profileID string) {
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+
+		entries := make([]map[string]interface{}, 0)
+		for _, e := range s.logs[profileID] {
+			if status := query.Get("status"); len(status) > 0 && e["status"] != status {
+				continue
+			}
+			if search := query.Get("search"); len(search) > 0 && !strings.Contains(fmt.Sprint(e["domain"]), search) {
+				continue
+			}
+			if device := query.Get("device"); len(device) > 0 {
+				d, _ := e["device"].(map[string]interface{})
+				if d == nil || d["id"] != device {
+					continue
+				}
+			}
+			entries = append(entries, e)
+		}
+
+		writePage(w, r, entries)
+	case http.MethodDelete:
+		delete(s.logs, profileID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "methodNotAllowed", "")
+	}
+}
+
+func (s *Server) createProfile(fields map[string]interface{}) string {
+	s.nextID++
+	id := fmt.Sprintf("%06x", s.nextID)
+
+	profile := defaultProfile(id)
+	if fields != nil {
+		for _, list := range []string{"denylist", "allowlist", "rewrites"} {
+			items, _ := fields[list].([]interface{})
+			for _, item := range items {
+				if obj, ok := item.(map[string]interface{}); ok {
+					applyDefaults(list, obj)
+					if list == "rewrites" {
+						s.nextID++
+						obj["id"] = fmt.Sprintf("%x", s.nextID)
+					}
+				}
+			}
+		}
+		merge(profile, deepCopy(fields).(map[string]interface{}))
+	}
+	s.profiles[id] = profile
+
+	return id
+}
+
+// defaultProfile returns a profile with the configuration of a new NextDNS profile.
+func defaultProfile(id string) map[string]interface{} {
+	return map[string]interface{}{
+		"name": "",
+		"security": map[string]interface{}{
+			"threatIntelligenceFeeds": true,
+			"aiThreatDetection":       true,
+			"googleSafeBrowsing":      true,
+			"cryptojacking":           true,
+			"dnsRebinding":            true,
+			"idnHomographs":           true,
+			"typosquatting":           true,
+			"dga":                     true,
+			"nrd":                     false,
+			"ddns":                    false,
+			"parking":                 true,
+			"csam":                    true,
+			"tlds":                    []interface{}{},
+		},
+		"privacy": map[string]interface{}{
+			"blocklists":        []interface{}{},
+			"natives":           []interface{}{},
+			"disguisedTrackers": true,
+			"allowAffiliate":    true,
+		},
+		"parentalControl": map[string]interface{}{
+			"services":              []interface{}{},
+			"categories":            []interface{}{},
+			"safeSearch":            false,
+			"youtubeRestrictedMode": false,
+			"blockBypass":           false,
+		},
+		"denylist":  []interface{}{},
+		"allowlist": []interface{}{},
+		"settings": map[string]interface{}{
+			"logs": map[string]interface{}{
+				"enabled": true,
+				"drop": map[string]interface{}{
+					"ip":     false,
+					"domain": false,
+				},
+				"retention": float64(7776000),
+				"location":  "us",
+			},
+			"blockPage": map[string]interface{}{
+				"enabled": true,
+			},
+			"performance": map[string]interface{}{
+				"ecs":             true,
+				"cacheBoost":      true,
+				"cnameFlattening": true,
+			},
+			"web3": true,
+		},
+		"rewrites": []interface{}{},
+		"setup": map[string]interface{}{
+			"ipv4": []interface{}{"45.90.28.0", "45.90.30.0"},
+			"ipv6": []interface{}{"2a07:a8c0::" + id, "2a07:a8c1::" + id},
+			"linkedIp": map[string]interface{}{
+				"servers":     []interface{}{"45.90.28.0", "45.90.30.0"},
+				"ip":          "203.0.113.1",
+				"ddns":        "",
+				"updateToken": "token" + id,
+			},
+			"dnscrypt": "sdns://" + id,
+		},
+	}
+}
+
+// applyDefaults sets the fields the NextDNS API adds to the entries of a list.
+func applyDefaults(list string, item map[string]interface{}) {
+	switch list {
+	case "denylist", "allowlist", "services", "categories":
+		if _, ok := item["active"]; !ok {
+			item["active"] = true
+		}
+	}
+	if list == "services" || list == "categories" {
+		if _, ok := item["recreation"]; !ok {
+			item["recreation"] = false
+		}
+	}
+	if list == "rewrites" {
+		item["type"] = rewriteType(fmt.Sprint(item["content"]))
+	}
+}
+
+// rewriteType returns the DNS record type of the rewrite content.
+func rewriteType(content string) string {
+	ip := net.ParseIP(content)
+	switch {
+	case ip == nil:
+		return "CNAME"
+	case ip.To4() != nil:
+		return "A"
+	default:
+		return "AAAA"
+	}
+}
+
+// child returns the element of the object or list at the given path segment, with the key to set it back.
+func child(node interface{}, seg string) (interface{}, string, bool) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for k, v := range n {
+			if strings.EqualFold(k, seg) {
+				return v, k, true
+			}
+		}
+	case []interface{}:
+		for _, e := range n {
+			if obj, ok := e.(map[string]interface{}); ok && obj["id"] == seg {
+				return obj, seg, true
+			}
+		}
+	}
+
+	return nil, "", false
+}
+
+// set replaces the element of the object or list identified by key.
+func set(node interface{}, key string, value interface{}) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		n[key] = value
+	case []interface{}:
+		for i, e := range n {
+			if e.(map[string]interface{})["id"] == key {
+				n[i] = value
+			}
+		}
+	}
+}
+
+// setPath replaces the element at the given path of the profile.
+func setPath(profile map[string]interface{}, path []string, value interface{}) {
+	var parent interface{}
+	var key string
+	var target interface{} = profile
+
+	for _, seg := range path {
+		next, k, _ := child(target, seg)
+		parent, key, target = target, k, next
+	}
+	set(parent, key, value)
+}
+
+// merge merges the patch into the object, nested objects are merged while other values are replaced.
+func merge(obj map[string]interface{}, patch map[string]interface{}) {
+	for k, v := range patch {
+		if nested, ok := v.(map[string]interface{}); ok {
+			if existing, ok := obj[k].(map[string]interface{}); ok {
+				merge(existing, nested)
+				continue
+			}
+		}
+		obj[k] = v
+	}
+}
+
+func deepCopy(v interface{}) interface{} {
+	b, _ := json.Marshal(v)
+
+	var out interface{}
+	_ = json.Unmarshal(b, &out)
+
+	return out
+}
+
+// writePage writes a page of items, following the limit and cursor query parameters.
+func writePage(w http.ResponseWriter, r *http.Request, items []map[string]interface{}) {
+	query := r.URL.Query()
+
+	start, _ := strconv.Atoi(query.Get("cursor"))
+	if start > len(items) {
+		start = len(items)
+	}
+
+	end := len(items)
+	if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit > 0 && start+limit < end {
+		end = start + limit
+	}
+
+	var cursor interface{}
+	if end < len(items) {
+		cursor = strconv.Itoa(end)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": items[start:end],
+		"meta": map[string]interface{}{
+			"pagination": map[string]interface{}{
+				"cursor": cursor,
+			},
+		},
+	})
+}
+
+func writeData(w http.ResponseWriter, data interface{}) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+}
+
+func writeError(w http.ResponseWriter, status int, code string, detail string) {
+	e := map[string]interface{}{"code": code}
+	if len(detail) > 0 {
+		e["detail"] = detail
+	}
+
+	writeJSON(w, status, map[string]interface{}{"errors": []interface{}{e}})
+}
+
+func writeErrorParameter(w http.ResponseWriter, status int, code string, parameter string) {
+	writeJSON(w, status, map[string]interface{}{
+		"errors": []interface{}{
+			map[string]interface{}{
+				"code":   code,
+				"source": map[string]interface{}{"parameter": parameter},
+			},
+		},
+	})
+}
+
+func writeErrorPointer(w http.ResponseWriter, status int, code string, pointer string) {
+	writeJSON(w, status, map[string]interface{}{
+		"errors": []interface{}{
+			map[string]interface{}{
+				"code":   code,
+				"source": map[string]interface{}{"pointer": pointer},
+			},
+		},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,156 @@
+package fakeapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/amalucelli/nextdns-go/nextdns"
+)
+
+func newClient(t *testing.T, s *Server) *nextdns.Client {
+	t.Helper()
+
+	client, err := nextdns.New(
+		nextdns.WithBaseURL(s.URL+"/"),
+		nextdns.WithAPIKey("test"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+func TestServerProfiles(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	ctx := context.Background()
+	client := newClient(t, s)
+
+	id, err := client.Profiles.Create(ctx, &nextdns.CreateProfileRequest{
+		Name:     "test",
+		Denylist: []*nextdns.Denylist{{ID: "example.com", Active: true}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profile, err := client.Profiles.Get(ctx, &nextdns.GetProfileRequest{ProfileID: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.Name != "test" || len(profile.Denylist) != 1 || !profile.Security.ThreatIntelligenceFeeds {
+		t.Errorf("unexpected profile: %+v", profile)
+	}
+
+	err = client.Profiles.Delete(ctx, &nextdns.DeleteProfileRequest{ProfileID: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Profiles.Get(ctx, &nextdns.GetProfileRequest{ProfileID: id})
+	var apiErr *nextdns.Error
+	if !errors.As(err, &apiErr) || apiErr.Type != nextdns.ErrorTypeNotFound {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestServerLists(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	ctx := context.Background()
+	client := newClient(t, s)
+	id := s.CreateProfile(nil)
+
+	err := client.SecurityTlds.Create(ctx, &nextdns.CreateSecurityTldsRequest{
+		ProfileID:    id,
+		SecurityTlds: []*nextdns.SecurityTlds{{ID: "ru"}, {ID: "cn"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.Denylist.Update(ctx, &nextdns.UpdateDenylistRequest{
+		ProfileID: id,
+		ID:        "missing.com",
+		Denylist:  &nextdns.Denylist{Active: false},
+	})
+	if err == nil {
+		t.Error("expected an error when updating a missing entry")
+	}
+
+	rewriteID, err := client.Rewrites.Create(ctx, &nextdns.CreateRewritesRequest{
+		ProfileID: id,
+		Rewrites:  &nextdns.Rewrites{Name: "example.com", Content: "2001:db8::1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rewrites, err := client.Rewrites.List(ctx, &nextdns.ListRewritesRequest{ProfileID: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rewrites) != 1 || rewrites[0].ID != rewriteID || rewrites[0].Type != "AAAA" {
+		t.Errorf("unexpected rewrites: %+v", rewrites)
+	}
+
+	err = client.Rewrites.Delete(ctx, &nextdns.DeleteRewritesRequest{ProfileID: id, ID: rewriteID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profile, _ := s.Profile(id)
+	if len(profile["rewrites"].([]interface{})) != 0 {
+		t.Errorf("expected rewrite to be deleted: %+v", profile["rewrites"])
+	}
+	if len(profile["security"].(map[string]interface{})["tlds"].([]interface{})) != 2 {
+		t.Errorf("unexpected tlds: %+v", profile["security"])
+	}
+}
+
+func TestServerAuthentication(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	client, err := nextdns.New(nextdns.WithBaseURL(s.URL + "/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Profiles.List(context.Background(), &nextdns.ListProfileRequest{})
+	var apiErr *nextdns.Error
+	if !errors.As(err, &apiErr) || apiErr.Type != nextdns.ErrorTypeAuthentication {
+		t.Errorf("expected authentication error, got %v", err)
+	}
+}
+
+func TestServerReject(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	ctx := context.Background()
+	client := newClient(t, s)
+	id := s.CreateProfile(nil)
+
+	s.Reject("bad.example.com")
+
+	err := client.Denylist.Create(ctx, &nextdns.CreateDenylistRequest{
+		ProfileID: id,
+		Denylist: []*nextdns.Denylist{
+			{ID: "good.example.com", Active: true},
+			{ID: "bad.example.com", Active: true},
+		},
+	})
+
+	var apiErr *nextdns.Error
+	if !errors.As(err, &apiErr) || apiErr.Type != nextdns.ErrorTypeRequest {
+		t.Fatalf("expected request error, got %v", err)
+	}
+	if body := apiErr.Meta["body"]; body != `{"errors":[{"code":"invalid","source":{"pointer":"/1/id"}}]}`+"\n" {
+		t.Errorf("unexpected error body %q", body)
+	}
+}
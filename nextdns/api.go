@@ -0,0 +1,199 @@
+package nextdns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/amalucelli/nextdns-go/nextdns"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// apiClient is a minimal client for the NextDNS API endpoints that are not covered by the nextdns-go client.
+type apiClient struct {
+	// client is shared with the nextdns-go client, which adds the API key to every request.
+	client  *http.Client
+	baseURL *url.URL
+}
+
+// profilePath returns the API path of an object within a profile.
+func profilePath(profileID string, elem ...string) string {
+	path := "profiles/" + url.PathEscape(profileID)
+	for _, e := range elem {
+		path += "/" + url.PathEscape(e)
+	}
+
+	return path
+}
+
+// apiMeta represents the metadata returned with the lists of the NextDNS API.
+type apiMeta struct {
+	Pagination struct {
+		Cursor string `json:"cursor"`
+	} `json:"pagination"`
+}
+
+// apiProfile represents a profile as listed by the NextDNS API.
+type apiProfile struct {
+	ID          string `json:"id"`
+	Fingerprint string `json:"fingerprint"`
+	Role        string `json:"role"`
+	Name        string `json:"name"`
+}
+
+// listProfiles returns every profile of the account.
+func (c *apiClient) listProfiles(ctx context.Context) ([]*apiProfile, error) {
+	items, err := c.list(ctx, "profiles", url.Values{}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make([]*apiProfile, 0, len(items))
+	for _, item := range items {
+		p := &apiProfile{}
+		if err := json.Unmarshal(item, p); err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, p)
+	}
+
+	return profiles, nil
+}
+
+// list returns the items of a list of the NextDNS API, following the cursor pagination
+// until every item is fetched or the limit is reached, if greater than zero.
+func (c *apiClient) list(ctx context.Context, path string, query url.Values, limit int) ([]json.RawMessage, error) {
+	var items []json.RawMessage
+
+	for {
+		var res struct {
+			Data []json.RawMessage `json:"data"`
+			Meta apiMeta           `json:"meta"`
+		}
+
+		p := path
+		if len(query) > 0 {
+			p += "?" + query.Encode()
+		}
+		if err := c.do(ctx, http.MethodGet, p, nil, &res); err != nil {
+			return nil, err
+		}
+		items = append(items, res.Data...)
+
+		if limit > 0 && len(items) >= limit {
+			return items[:limit], nil
+		}
+
+		cursor := res.Meta.Pagination.Cursor
+		if len(cursor) == 0 {
+			return items, nil
+		}
+		query.Set("cursor", cursor)
+	}
+}
+
+// addListEntry adds a single entry to a list of a profile (e.g. denylist or security/tlds).
+func (c *apiClient) addListEntry(ctx context.Context, profileID string, list string, entry interface{}) error {
+	return c.do(ctx, http.MethodPost, profilePath(profileID)+"/"+list, entry, nil)
+}
+
+// deleteListEntry removes a single entry from a list of a profile (e.g. denylist or security/tlds).
+func (c *apiClient) deleteListEntry(ctx context.Context, profileID string, list string, id string) error {
+	return c.do(ctx, http.MethodDelete, profilePath(profileID)+"/"+list+"/"+url.PathEscape(id), nil, nil)
+}
+
+// do sends a request to the NextDNS API and decodes the response into v if provided.
+// Errors are returned as *nextdns.Error, the same way as the nextdns-go client does.
+func (c *apiClient) do(ctx context.Context, method string, path string, body interface{}, v interface{}) error {
+	u, err := c.baseURL.Parse(path)
+	if err != nil {
+		return err
+	}
+
+	var reader io.Reader
+	if body != nil {
+		buf := new(bytes.Buffer)
+		if err := json.NewEncoder(buf).Encode(body); err != nil {
+			return err
+		}
+		reader = buf
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %s %s", method, u.String()))
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	out, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	meta := map[string]string{
+		"body":        string(out),
+		"http_status": http.StatusText(res.StatusCode),
+	}
+
+	// The API can also report errors with an HTTP 200 (e.g. duplicated entries).
+	if res.StatusCode >= http.StatusBadRequest || strings.Contains(string(out), "\"errors\"") {
+		errorRes := &nextdns.ErrorResponse{}
+		if res.StatusCode < http.StatusInternalServerError {
+			// The error body is best effort, as the status code is enough to report the error.
+			_ = json.Unmarshal(out, errorRes)
+		}
+
+		var errType nextdns.ErrorType
+		switch {
+		case res.StatusCode >= http.StatusInternalServerError:
+			errType = nextdns.ErrorTypeServiceError
+		case res.StatusCode == http.StatusForbidden:
+			errType = nextdns.ErrorTypeAuthentication
+		case res.StatusCode == http.StatusNotFound:
+			errType = nextdns.ErrorTypeNotFound
+		default:
+			errType = nextdns.ErrorTypeRequest
+		}
+
+		return &nextdns.Error{
+			Type:    errType,
+			Message: fmt.Sprintf("response error received (%s %s)", method, path),
+			Errors:  errorRes,
+			Meta:    meta,
+		}
+	}
+
+	if v == nil || len(out) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(out, v); err != nil {
+		return &nextdns.Error{
+			Type:    nextdns.ErrorTypeMalformed,
+			Message: "malformed response body received",
+			Errors:  &nextdns.ErrorResponse{},
+			Meta:    meta,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,233 @@
+package nextdns
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/amalucelli/nextdns-go/nextdns"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// apiErrorBody is the error body returned by the NextDNS API.
+type apiErrorBody struct {
+	Errors []apiErrorEntry `json:"errors"`
+}
+
+// apiErrorEntry is an error reported by the NextDNS API, pointing to the offending value of the request if any.
+type apiErrorEntry struct {
+	Code   string `json:"code"`
+	Detail string `json:"detail"`
+	Source struct {
+		Pointer   string `json:"pointer"`
+		Parameter string `json:"parameter"`
+	} `json:"source"`
+}
+
+// message returns the description of the error.
+func (e apiErrorEntry) message() string {
+	if len(e.Detail) == 0 {
+		return e.Code
+	}
+
+	return fmt.Sprintf("%s (%s)", e.Detail, e.Code)
+}
+
+// entryError is the error of a request made for a single entry of a list (e.g. adding a domain to the denylist).
+type entryError struct {
+	action string
+	id     string
+	err    error
+}
+
+// Error returns the description of the error, naming the entry.
+func (e *entryError) Error() string {
+	return fmt.Sprintf("error %s %q: %s", e.action, e.id, apiErrorMessage(e.err))
+}
+
+// Unwrap returns the error of the request.
+func (e *entryError) Unwrap() error {
+	return e.err
+}
+
+// apiErrorTarget binds a list sent to the NextDNS API to the attribute declaring its elements,
+// so the errors reported for an element are attached to the element in the configuration.
+type apiErrorTarget struct {
+	// pointer is the JSON pointer of the list within the request body, empty when the body is the list itself.
+	pointer string
+	// path is the path of the attribute declaring the list.
+	path cty.Path
+	// ids are the identifiers of the elements, in the order they were sent.
+	ids []string
+	// indexed reports whether the attribute is a list, so the index of the element is kept in its path.
+	// The elements of a set cannot be addressed, so the errors are attached to the set itself.
+	indexed bool
+	// field reports whether the pointer designates a single value instead of a list (e.g. the name of a rewrite).
+	field bool
+}
+
+// listErrorTarget returns the target of a list of identifiers (e.g. tlds).
+func listErrorTarget(pointer string, d *schema.ResourceData, key string) apiErrorTarget {
+	return apiErrorTarget{
+		pointer: pointer,
+		path:    attributePath(key),
+		ids:     stringsFromList(d.Get(key)),
+		indexed: true,
+	}
+}
+
+// setErrorTarget returns the target of a set of blocks, identified by one of their attributes (e.g. id).
+func setErrorTarget(pointer string, d *schema.ResourceData, key string, idKey string) apiErrorTarget {
+	target := apiErrorTarget{
+		pointer: pointer,
+		path:    attributePath(key),
+	}
+
+	if set, ok := d.Get(key).(*schema.Set); ok {
+		for _, e := range set.List() {
+			target.ids = append(target.ids, e.(map[string]interface{})[idKey].(string))
+		}
+	}
+
+	return target
+}
+
+// fieldErrorTarget returns the target of a single value of the object sent to the API (e.g. /name),
+// declared by the attribute.
+func fieldErrorTarget(pointer string, d *schema.ResourceData, key string) apiErrorTarget {
+	return apiErrorTarget{
+		pointer: pointer,
+		path:    attributePath(key),
+		ids:     []string{fmt.Sprint(d.Get(key))},
+		field:   true,
+	}
+}
+
+// find returns the path of the element with the given identifier.
+func (t apiErrorTarget) find(id string) (cty.Path, bool) {
+	for i, e := range t.ids {
+		if e == id {
+			return t.elementPath(i), true
+		}
+	}
+
+	return nil, false
+}
+
+// resolve returns the identifier and the path of the element designated by the JSON pointer of an error.
+func (t apiErrorTarget) resolve(pointer string) (string, cty.Path, bool) {
+	if t.field {
+		return t.ids[0], t.path, pointer == t.pointer
+	}
+
+	if !strings.HasPrefix(pointer, t.pointer+"/") {
+		return "", nil, false
+	}
+
+	index, _, _ := strings.Cut(strings.TrimPrefix(pointer, t.pointer+"/"), "/")
+	i, err := strconv.Atoi(index)
+	if err != nil || i < 0 || i >= len(t.ids) {
+		return "", nil, false
+	}
+
+	return t.ids[i], t.elementPath(i), true
+}
+
+// elementPath returns the path of the element at the given index.
+func (t apiErrorTarget) elementPath(i int) cty.Path {
+	if !t.indexed {
+		return t.path
+	}
+
+	return t.path.Copy().IndexInt(i)
+}
+
+// attributePath returns the path of a flattened attribute key (e.g. security.0.tlds).
+func attributePath(key string) cty.Path {
+	var path cty.Path
+	for _, step := range strings.Split(key, ".") {
+		if i, err := strconv.Atoi(step); err == nil {
+			path = path.IndexInt(i)
+		} else {
+			path = path.GetAttr(step)
+		}
+	}
+
+	return path
+}
+
+// apiErrorDiags returns the diagnostics of a failed request to the NextDNS API, one for each error it reported.
+// The errors pointing to an element of one of the targets are attached to the attribute declaring the element.
+func apiErrorDiags(err error, summary string, targets ...apiErrorTarget) diag.Diagnostics {
+	var apiErr *nextdns.Error
+	if !errors.As(err, &apiErr) {
+		return diag.FromErr(fmt.Errorf("%s: %w", summary, err))
+	}
+
+	// The entry is known when the request was made for a single entry of a list.
+	var entry *entryError
+	errors.As(err, &entry)
+
+	var body apiErrorBody
+	_ = json.Unmarshal([]byte(apiErr.Meta["body"]), &body)
+
+	if len(body.Errors) == 0 {
+		// Unlike its Error method, this does not panic for the errors without a body (e.g. internal errors).
+		body.Errors = []apiErrorEntry{{Code: apiErr.Meta["http_status"], Detail: apiErr.Message}}
+	}
+
+	diags := make(diag.Diagnostics, 0, len(body.Errors))
+	for _, e := range body.Errors {
+		d := diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   e.message(),
+		}
+
+		var id string
+		var path cty.Path
+		var ok bool
+		for _, t := range targets {
+			if entry != nil {
+				id = entry.id
+				path, ok = t.find(id)
+			} else {
+				id, path, ok = t.resolve(e.Source.Pointer)
+			}
+			if ok {
+				break
+			}
+		}
+
+		switch {
+		case ok:
+			d.Detail = fmt.Sprintf("The NextDNS API rejected %q: %s", id, d.Detail)
+			d.AttributePath = path
+		case entry != nil:
+			d.Detail = fmt.Sprintf("Error %s %q: %s", entry.action, entry.id, d.Detail)
+		case len(e.Source.Pointer) > 0:
+			d.Detail = fmt.Sprintf("%s, at %s in the request", d.Detail, e.Source.Pointer)
+		case len(e.Source.Parameter) > 0:
+			d.Detail = fmt.Sprintf("%s, for the %s parameter", d.Detail, e.Source.Parameter)
+		}
+
+		diags = append(diags, d)
+	}
+
+	return diags
+}
+
+// apiErrorMessage returns the description of an error, which unlike the Error method of the errors
+// of the NextDNS API does not panic for the errors without a body (e.g. internal errors).
+func apiErrorMessage(err error) string {
+	var apiErr *nextdns.Error
+	if errors.As(err, &apiErr) && apiErr == err && apiErr.Errors == nil {
+		return fmt.Sprintf("%s (%s)", apiErr.Message, apiErr.Meta["http_status"])
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,98 @@
+package nextdns
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/amalucelli/nextdns-go/nextdns"
+	"github.com/amalucelli/terraform-provider-nextdns/internal/fakeapi"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAPIErrorDiags(t *testing.T) {
+	s := fakeapi.New()
+	defer s.Close()
+
+	meta, err := newProviderMeta(&clientConfig{apiKey: "test", apiURL: s.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profileID := s.CreateProfile(nil)
+	s.Reject("bad")
+
+	tests := map[string]struct {
+		resource *schema.Resource
+		raw      map[string]interface{}
+		path     cty.Path
+	}{
+		"list": {
+			resource: resourceNextDNSPrivacy(),
+			raw: map[string]interface{}{
+				"profile_id":         profileID,
+				"disguised_trackers": true,
+				"allow_affiliate":    true,
+				"blocklists":         []interface{}{"nextdns-recommended", "bad"},
+			},
+			path: cty.GetAttrPath("blocklists").IndexInt(1),
+		},
+		"set": {
+			resource: resourceNextDNSDenylist(),
+			raw: map[string]interface{}{
+				"profile_id": profileID,
+				"domain": []interface{}{
+					map[string]interface{}{"id": "good.example.com", "active": true},
+					map[string]interface{}{"id": "bad", "active": true},
+				},
+			},
+			path: cty.GetAttrPath("domain"),
+		},
+		"entry": {
+			resource: resourceNextDNSDenylist(),
+			raw: map[string]interface{}{
+				"profile_id":    profileID,
+				"authoritative": false,
+				"domain": []interface{}{
+					map[string]interface{}{"id": "bad", "active": true},
+				},
+			},
+			path: cty.GetAttrPath("domain"),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, tt.resource.Schema, tt.raw)
+
+			diags := tt.resource.CreateContext(context.Background(), d, meta)
+			if len(diags) != 1 {
+				t.Fatalf("expected a single diagnostic, got %+v", diags)
+			}
+			if !diags[0].AttributePath.Equals(tt.path) {
+				t.Errorf("expected path %#v, got %#v", tt.path, diags[0].AttributePath)
+			}
+			if !strings.Contains(diags[0].Detail, `"bad"`) {
+				t.Errorf("expected the rejected value in the detail, got %q", diags[0].Detail)
+			}
+		})
+	}
+}
+
+func TestAPIErrorDiagsWithoutBody(t *testing.T) {
+	err := &nextdns.Error{
+		Type:    nextdns.ErrorTypeServiceError,
+		Message: "internal service error received",
+		Meta:    map[string]string{"http_status": "Internal Server Error"},
+	}
+
+	diags := apiErrorDiags(err, "error getting profile")
+	if len(diags) != 1 || diags[0].Detail != "internal service error received (Internal Server Error)" {
+		t.Errorf("unexpected diagnostics %+v", diags)
+	}
+
+	if msg := (&entryError{action: "adding", id: "example.com", err: err}).Error(); !strings.Contains(msg, "internal service error") {
+		t.Errorf("unexpected message %q", msg)
+	}
+}
@@ -0,0 +1,374 @@
+package nextdns
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amalucelli/nextdns-go/nextdns"
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// defaultAPIURL is the base URL of the NextDNS API.
+	defaultAPIURL = "https://api.nextdns.io/"
+	// defaultMaxRetries is the default number of retries for a failed request.
+	defaultMaxRetries = 3
+	// defaultRetryMinWait is the base wait time before retrying a request.
+	defaultRetryMinWait = time.Second
+	// defaultRetryMaxWait is the default maximum wait time between retries.
+	defaultRetryMaxWait = 30 * time.Second
+)
+
+// clientConfig holds the settings used to build the client for the NextDNS API.
+type clientConfig struct {
+	apiKey   string
+	apiURL   string
+	timeout  time.Duration
+	proxyURL string
+	caBundle string
+	headers  map[string]string
+
+	maxRetries   int
+	retryMaxWait time.Duration
+}
+
+// providerMeta is the object passed to every resource and data source of the provider.
+type providerMeta struct {
+	client *nextdns.Client
+	api    *apiClient
+
+	// profiles caches the profiles read by the resources, so a refresh fetches every profile only once.
+	profiles *profileCache
+	// profileLocks serializes the changes made to a same profile by the resources applied in parallel.
+	profileLocks *mutexKV
+}
+
+// newProviderMeta returns the clients for the NextDNS API configured with the given settings.
+func newProviderMeta(cfg *clientConfig) (*providerMeta, error) {
+	httpClient, err := newHTTPClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	apiURL := defaultAPIURL
+	if len(cfg.apiURL) > 0 {
+		apiURL = cfg.apiURL
+	}
+	// The API paths are resolved relative to the base URL, so it must end with a slash.
+	if !strings.HasSuffix(apiURL, "/") {
+		apiURL += "/"
+	}
+
+	baseURL, err := url.Parse(apiURL)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing api url: %w", err)
+	}
+
+	// Every request goes through the cache transport, so the changes made by any client invalidate the cached profiles.
+	profiles := newProfileCache()
+	httpClient.Transport = &profileCacheTransport{
+		rt:       httpClient.Transport,
+		cache:    profiles,
+		basePath: baseURL.Path,
+	}
+
+	// The HTTP client must be set first, as the API key option wraps its transport.
+	client, err := nextdns.New(
+		nextdns.WithHTTPClient(httpClient),
+		nextdns.WithBaseURL(apiURL),
+		nextdns.WithAPIKey(cfg.apiKey),
+	)
+	if err != nil {
+		return nil, err
+	}
+	profiles.client = client
+
+	return &providerMeta{
+		client: client,
+		api: &apiClient{
+			client:  httpClient,
+			baseURL: baseURL,
+		},
+		profiles:     profiles,
+		profileLocks: newMutexKV(),
+	}, nil
+}
+
+// newHTTPClient returns the HTTP client used to reach the NextDNS API.
+func newHTTPClient(cfg *clientConfig) (*http.Client, error) {
+	transport := cleanhttp.DefaultPooledTransport()
+
+	if len(cfg.proxyURL) > 0 {
+		proxyURL, err := url.Parse(cfg.proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing http proxy url: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if len(cfg.caBundle) > 0 {
+		pool, err := loadCABundle(cfg.caBundle)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			RootCAs:    pool,
+		}
+	}
+
+	var rt http.RoundTripper = transport
+	if len(cfg.headers) > 0 {
+		rt = &headerTransport{
+			rt:      rt,
+			headers: cfg.headers,
+		}
+	}
+
+	// The timeout applies to each attempt, rather than to the whole request with its retries and waits.
+	rt = &retryTransport{
+		rt:         rt,
+		maxRetries: cfg.maxRetries,
+		minWait:    defaultRetryMinWait,
+		maxWait:    cfg.retryMaxWait,
+		timeout:    cfg.timeout,
+	}
+
+	return &http.Client{
+		Transport: rt,
+	}, nil
+}
+
+// loadCABundle returns the system certificate pool extended with the certificates from the given PEM file.
+func loadCABundle(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading ca bundle: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		// nolint:goerr113
+		return nil, errors.New("no valid certificates found in ca bundle")
+	}
+
+	return pool, nil
+}
+
+// headerTransport represents a RoundTripper that adds extra headers to the request.
+type headerTransport struct {
+	rt      http.RoundTripper
+	headers map[string]string
+}
+
+// RoundTrip adds the extra headers to requests.
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+
+	return t.rt.RoundTrip(req)
+}
+
+// retryTransport represents a RoundTripper that retries failed requests with a jittered exponential backoff.
+type retryTransport struct {
+	rt         http.RoundTripper
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+	// timeout is the timeout of each attempt, until the body of the response is closed. Zero means no timeout.
+	timeout time.Duration
+}
+
+// RoundTrip sends the request, retrying it while the failure is considered transient.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		res, err := t.roundTripAttempt(req)
+		if attempt >= t.maxRetries || !canRewind(req) || !shouldRetry(req, res, err) {
+			return res, err
+		}
+
+		wait := t.backoff(attempt, res)
+		if res != nil {
+			tflog.Warn(req.Context(), fmt.Sprintf("retrying request to nextdns api in %s: %s %s returned %s", wait, req.Method, req.URL.Path, res.Status))
+
+			// The body must be consumed and closed so the connection can be reused.
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		} else {
+			tflog.Warn(req.Context(), fmt.Sprintf("retrying request to nextdns api in %s: %s %s failed: %s", wait, req.Method, req.URL.Path, err))
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// canRewind reports whether the body of the request can be sent again, as the attempt consumed it.
+func canRewind(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// roundTripAttempt sends the request once, within the timeout of an attempt.
+func (t *retryTransport) roundTripAttempt(req *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 {
+		return t.rt.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	res, err := t.rt.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// The timeout also covers reading the body, so the context is only released once the body is closed.
+	res.Body = &cancelBody{ReadCloser: res.Body, cancel: cancel}
+
+	return res, nil
+}
+
+// cancelBody represents the body of a response which releases the context of its request when closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and releases the context of the request.
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+
+	return err
+}
+
+// backoff returns how long to wait before the next attempt, honouring the Retry-After header when present.
+func (t *retryTransport) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if wait, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			if wait > t.maxWait {
+				return t.maxWait
+			}
+			return wait
+		}
+	}
+
+	wait := t.minWait << attempt
+	if wait <= 0 || wait > t.maxWait {
+		wait = t.maxWait
+	}
+
+	// Full jitter between half and the whole backoff, so parallel requests do not retry in lockstep.
+	half := int64(wait / 2)
+	if half <= 0 {
+		return wait
+	}
+
+	// nolint:gosec
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+// shouldRetry reports whether a request should be retried given its response or error.
+// Rate limited requests were not processed by the API so they are always retried,
+// other transient failures are only retried for idempotent requests.
+func shouldRetry(req *http.Request, res *http.Response, err error) bool {
+	if err != nil {
+		// Requests cancelled by the caller must not be retried.
+		if req.Context().Err() != nil {
+			return false
+		}
+		return isIdempotent(req.Method)
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	default:
+		return false
+	}
+}
+
+// isIdempotent reports whether the HTTP method is idempotent.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter parses the Retry-After header, which can either be in seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if len(value) == 0 {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// parseHeaders parses a comma separated list of key=value pairs into a map of headers.
+func parseHeaders(raw string) (map[string]string, error) {
+	headers := make(map[string]string)
+
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if len(pair) == 0 {
+			continue
+		}
+
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || len(strings.TrimSpace(key)) == 0 {
+			// nolint:goerr113
+			return nil, fmt.Errorf("invalid header %q, must be in key=value format", pair)
+		}
+		headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return headers, nil
+}
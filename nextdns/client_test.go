@@ -0,0 +1,433 @@
+package nextdns
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		statuses   []int
+		retryAfter string
+		maxRetries int
+		wantStatus int
+		wantCalls  int32
+	}{
+		{
+			name:       "rate limited with retry after in seconds",
+			method:     http.MethodPost,
+			statuses:   []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter: "0",
+			maxRetries: 3,
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+		{
+			name:       "rate limited with retry after as a date",
+			method:     http.MethodPatch,
+			statuses:   []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter: time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat),
+			maxRetries: 3,
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+		{
+			name:       "server error on an idempotent request",
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			maxRetries: 3,
+			wantStatus: http.StatusOK,
+			wantCalls:  3,
+		},
+		{
+			name:       "server error on a non idempotent request",
+			method:     http.MethodPost,
+			statuses:   []int{http.StatusServiceUnavailable, http.StatusOK},
+			maxRetries: 3,
+			wantStatus: http.StatusServiceUnavailable,
+			wantCalls:  1,
+		},
+		{
+			name:       "client error",
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusBadRequest, http.StatusOK},
+			maxRetries: 3,
+			wantStatus: http.StatusBadRequest,
+			wantCalls:  1,
+		},
+		{
+			name:       "stops after the maximum retries",
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusServiceUnavailable},
+			maxRetries: 2,
+			wantStatus: http.StatusServiceUnavailable,
+			wantCalls:  3,
+		},
+		{
+			name:       "retries disabled",
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusTooManyRequests, http.StatusOK},
+			maxRetries: 0,
+			wantStatus: http.StatusTooManyRequests,
+			wantCalls:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(atomic.AddInt32(&calls, 1))
+				status := tt.statuses[len(tt.statuses)-1]
+				if n <= len(tt.statuses) {
+					status = tt.statuses[n-1]
+				}
+
+				// The body of the retried requests must be sent again.
+				if body, _ := io.ReadAll(r.Body); r.Method != http.MethodGet && string(body) != "{}" {
+					t.Errorf("unexpected body %q", body)
+				}
+				if len(tt.retryAfter) > 0 {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+
+			client := &http.Client{
+				Transport: &retryTransport{
+					rt:         http.DefaultTransport,
+					maxRetries: tt.maxRetries,
+					minWait:    time.Millisecond,
+					maxWait:    10 * time.Millisecond,
+				},
+			}
+
+			var body io.Reader
+			if tt.method != http.MethodGet {
+				body = strings.NewReader("{}")
+			}
+			req, err := http.NewRequest(tt.method, server.URL, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			res.Body.Close()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+// failingTransport represents a RoundTripper failing every request, as when the API cannot be reached.
+type failingTransport struct {
+	calls int
+}
+
+func (t *failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	t.calls++
+
+	// nolint:goerr113
+	return nil, errors.New("connection reset by peer")
+}
+
+func TestRetryTransport_transportError(t *testing.T) {
+	tests := []struct {
+		method    string
+		wantCalls int
+	}{
+		{method: http.MethodGet, wantCalls: 3},
+		{method: http.MethodDelete, wantCalls: 3},
+		{method: http.MethodPost, wantCalls: 1},
+		{method: http.MethodPatch, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			failing := &failingTransport{}
+			rt := &retryTransport{
+				rt:         failing,
+				maxRetries: 2,
+				minWait:    time.Millisecond,
+				maxWait:    time.Millisecond,
+			}
+
+			req, err := http.NewRequest(tt.method, "http://nextdns.test/profiles", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := rt.RoundTrip(req); err == nil {
+				t.Error("expected an error")
+			}
+			if failing.calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", failing.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_bodyNotRewindable(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if body, _ := io.ReadAll(r.Body); string(body) != "{}" {
+			t.Errorf("unexpected body %q", body)
+		}
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: &retryTransport{
+			rt:         http.DefaultTransport,
+			maxRetries: 3,
+			minWait:    time.Millisecond,
+			maxWait:    time.Millisecond,
+		},
+	}
+
+	// The body is not one of the readers http.NewRequest knows how to rewind.
+	req, err := http.NewRequest(http.MethodPost, server.URL, io.NopCloser(strings.NewReader("{}")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.GetBody != nil {
+		t.Fatal("expected a body which cannot be rewound")
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("status = %d, want %d", res.StatusCode, http.StatusTooManyRequests)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestRetryTransport_cancelDuringBackoff(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	rt := &retryTransport{
+		rt:         http.DefaultTransport,
+		maxRetries: 3,
+		minWait:    time.Millisecond,
+		maxWait:    time.Minute,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	if _, err := rt.RoundTrip(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the context error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("the wait was not interrupted, returned after %s", elapsed)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestRetryTransport_attemptTimeout(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: &retryTransport{
+			rt:         http.DefaultTransport,
+			maxRetries: 1,
+			minWait:    time.Millisecond,
+			maxWait:    time.Millisecond,
+			timeout:    100 * time.Millisecond,
+		},
+	}
+
+	res, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer res.Body.Close()
+
+	// The body is read after the attempt returned, within its timeout.
+	body, err := io.ReadAll(res.Body)
+	if err != nil || string(body) != "ok" {
+		t.Errorf("unexpected body %q: %v", body, err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	rt := &retryTransport{
+		minWait: time.Second,
+		maxWait: 30 * time.Second,
+	}
+
+	tests := []struct {
+		name       string
+		attempt    int
+		retryAfter string
+		min, max   time.Duration
+	}{
+		{name: "retry after in seconds", retryAfter: "5", min: 5 * time.Second, max: 5 * time.Second},
+		{name: "retry after as a date", retryAfter: time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat), min: 8 * time.Second, max: 10 * time.Second},
+		{name: "retry after capped", retryAfter: "3600", min: 30 * time.Second, max: 30 * time.Second},
+		{name: "first attempt", attempt: 0, min: 500 * time.Millisecond, max: time.Second},
+		{name: "exponential", attempt: 3, min: 4 * time.Second, max: 8 * time.Second},
+		{name: "capped", attempt: 10, min: 15 * time.Second, max: 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &http.Response{Header: http.Header{}}
+			if len(tt.retryAfter) > 0 {
+				res.Header.Set("Retry-After", tt.retryAfter)
+			}
+
+			if wait := rt.backoff(tt.attempt, res); wait < tt.min || wait > tt.max {
+				t.Errorf("backoff = %s, want between %s and %s", wait, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name   string
+		method string
+		ctx    context.Context
+		status int
+		err    error
+		want   bool
+	}{
+		{name: "rate limited get", method: http.MethodGet, status: http.StatusTooManyRequests, want: true},
+		{name: "rate limited post", method: http.MethodPost, status: http.StatusTooManyRequests, want: true},
+		{name: "bad gateway get", method: http.MethodGet, status: http.StatusBadGateway, want: true},
+		{name: "unavailable put", method: http.MethodPut, status: http.StatusServiceUnavailable, want: true},
+		{name: "gateway timeout patch", method: http.MethodPatch, status: http.StatusGatewayTimeout, want: false},
+		{name: "internal error get", method: http.MethodGet, status: http.StatusInternalServerError, want: false},
+		{name: "not found get", method: http.MethodGet, status: http.StatusNotFound, want: false},
+		{name: "ok", method: http.MethodGet, status: http.StatusOK, want: false},
+		{name: "transport error get", method: http.MethodGet, err: io.ErrUnexpectedEOF, want: true},
+		{name: "transport error post", method: http.MethodPost, err: io.ErrUnexpectedEOF, want: false},
+		{name: "cancelled get", method: http.MethodGet, ctx: cancelled, err: context.Canceled, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			req, err := http.NewRequestWithContext(ctx, tt.method, "http://nextdns.test/profiles", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var res *http.Response
+			if tt.err == nil {
+				res = &http.Response{StatusCode: tt.status}
+			}
+
+			if got := shouldRetry(req, res, tt.err); got != tt.want {
+				t.Errorf("shouldRetry() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsIdempotent(t *testing.T) {
+	tests := map[string]bool{
+		http.MethodGet:     true,
+		http.MethodHead:    true,
+		http.MethodOptions: true,
+		http.MethodPut:     true,
+		http.MethodDelete:  true,
+		http.MethodPost:    false,
+		http.MethodPatch:   false,
+	}
+
+	for method, want := range tests {
+		if got := isIdempotent(method); got != want {
+			t.Errorf("isIdempotent(%q) = %t, want %t", method, got, want)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		min, max time.Duration
+		ok       bool
+	}{
+		{name: "empty"},
+		{name: "seconds", value: "12", min: 12 * time.Second, max: 12 * time.Second, ok: true},
+		{name: "zero", value: "0", ok: true},
+		{name: "negative", value: "-1"},
+		{name: "date", value: time.Now().Add(time.Minute).UTC().Format(http.TimeFormat), min: 58 * time.Second, max: time.Minute, ok: true},
+		{name: "past date", value: time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), ok: true},
+		{name: "invalid", value: "soon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, ok := parseRetryAfter(tt.value)
+			if ok != tt.ok {
+				t.Fatalf("ok = %t, want %t", ok, tt.ok)
+			}
+			if wait < tt.min || wait > tt.max {
+				t.Errorf("wait = %s, want between %s and %s", wait, tt.min, tt.max)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package nextdns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// analyticsMaxPageSize is the maximum number of items returned by a single analytics request.
+const analyticsMaxPageSize = 500
+
+// analyticsSchema returns the schema of an analytics data source, with the filters shared by every endpoint.
+func analyticsSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["profile_id"] = &schema.Schema{
+		Description: "The profile identifier to target the data source.",
+		Type:        schema.TypeString,
+		Required:    true,
+	}
+	s["from"] = &schema.Schema{
+		Description: "Start of the time range, as an ISO 8601 date, a UNIX timestamp or a relative date (e.g. -7d).",
+		Type:        schema.TypeString,
+		Optional:    true,
+	}
+	s["to"] = &schema.Schema{
+		Description: "End of the time range, as an ISO 8601 date, a UNIX timestamp or a relative date (e.g. now).",
+		Type:        schema.TypeString,
+		Optional:    true,
+	}
+	s["limit"] = &schema.Schema{
+		Description:  "Maximum number of items to return, every item is returned by default.",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+	}
+	s["device"] = &schema.Schema{
+		Description: "Device identifier to filter the queries by.",
+		Type:        schema.TypeString,
+		Optional:    true,
+	}
+
+	return s
+}
+
+// readAnalytics returns the items of an analytics endpoint of the profile, with the filters of the data source.
+func readAnalytics(ctx context.Context, d *schema.ResourceData, meta interface{}, endpoint string, query url.Values) ([]json.RawMessage, diag.Diagnostics) {
+	api := meta.(*providerMeta).api
+	profileID := d.Get("profile_id").(string)
+
+	for _, key := range []string{"from", "to", "device"} {
+		if v, ok := d.GetOk(key); ok {
+			query.Set(key, v.(string))
+		}
+	}
+
+	limit := d.Get("limit").(int)
+	pageSize := analyticsMaxPageSize
+	if limit > 0 && limit < pageSize {
+		pageSize = limit
+	}
+	query.Set("limit", strconv.Itoa(pageSize))
+	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %s %+v", endpoint, query))
+
+	items, err := api.list(ctx, profilePath(profileID, "analytics", endpoint), query, limit)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, diag.Errorf("profile %q not found", profileID)
+		}
+		return nil, apiErrorDiags(err, fmt.Sprintf("error getting analytics %s", endpoint))
+	}
+
+	d.SetId(profileID)
+
+	return items, nil
+}
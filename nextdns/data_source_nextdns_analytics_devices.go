@@ -0,0 +1,86 @@
+package nextdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceNextDNSAnalyticsDevices() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNextDNSAnalyticsDevicesRead,
+		Schema: analyticsSchema(map[string]*schema.Schema{
+			"devices": {
+				Description: "The number of queries by device.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "The device identifier, __UNIDENTIFIED__ for unidentified devices.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "The device name.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"model": {
+							Description: "The device model.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"local_ip": {
+							Description: "The local IP of the device.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"queries": {
+							Description: "The number of queries.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func dataSourceNextDNSAnalyticsDevicesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	items, diags := readAnalytics(ctx, d, meta, "devices", url.Values{})
+	if diags.HasError() {
+		return diags
+	}
+
+	var devices []map[string]interface{}
+	for _, item := range items {
+		var device struct {
+			ID      string `json:"id"`
+			Name    string `json:"name"`
+			Model   string `json:"model"`
+			LocalIP string `json:"localIp"`
+			Queries int    `json:"queries"`
+		}
+		if err := json.Unmarshal(item, &device); err != nil {
+			return diag.FromErr(err)
+		}
+
+		devices = append(devices, map[string]interface{}{
+			"id":       device.ID,
+			"name":     device.Name,
+			"model":    device.Model,
+			"local_ip": device.LocalIP,
+			"queries":  device.Queries,
+		})
+	}
+	if err := d.Set("devices", devices); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
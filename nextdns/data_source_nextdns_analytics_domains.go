@@ -0,0 +1,93 @@
+package nextdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceNextDNSAnalyticsDomains() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNextDNSAnalyticsDomainsRead,
+		Schema: analyticsSchema(map[string]*schema.Schema{
+			"status": {
+				Description:  "Only count the queries with this resolution status (blocked or allowed).",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"blocked", "allowed"}, false),
+			},
+			"root": {
+				Description: "Group the queries by root domain instead of full domain.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"domains": {
+				Description: "The number of queries by domain.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"domain": {
+							Description: "The queried domain.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"root": {
+							Description: "The root domain of the queried domain.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"queries": {
+							Description: "The number of queries.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func dataSourceNextDNSAnalyticsDomainsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	query := url.Values{}
+	if v, ok := d.GetOk("status"); ok {
+		query.Set("status", v.(string))
+	}
+	if d.Get("root").(bool) {
+		query.Set("root", "true")
+	}
+
+	items, diags := readAnalytics(ctx, d, meta, "domains", query)
+	if diags.HasError() {
+		return diags
+	}
+
+	var domains []map[string]interface{}
+	for _, item := range items {
+		var domain struct {
+			Domain  string `json:"domain"`
+			Root    string `json:"root"`
+			Queries int    `json:"queries"`
+		}
+		if err := json.Unmarshal(item, &domain); err != nil {
+			return diag.FromErr(err)
+		}
+
+		domains = append(domains, map[string]interface{}{
+			"domain":  domain.Domain,
+			"root":    domain.Root,
+			"queries": domain.Queries,
+		})
+	}
+	if err := d.Set("domains", domains); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package nextdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceNextDNSAnalyticsProtocols() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNextDNSAnalyticsProtocolsRead,
+		Schema: analyticsSchema(map[string]*schema.Schema{
+			"protocols": {
+				Description: "The number of queries by protocol.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"protocol": {
+							Description: "The protocol (e.g. DNS-over-HTTPS).",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"queries": {
+							Description: "The number of queries.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func dataSourceNextDNSAnalyticsProtocolsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	items, diags := readAnalytics(ctx, d, meta, "protocols", url.Values{})
+	if diags.HasError() {
+		return diags
+	}
+
+	var protocols []map[string]interface{}
+	for _, item := range items {
+		var protocol struct {
+			Protocol string `json:"protocol"`
+			Queries  int    `json:"queries"`
+		}
+		if err := json.Unmarshal(item, &protocol); err != nil {
+			return diag.FromErr(err)
+		}
+
+		protocols = append(protocols, map[string]interface{}{
+			"protocol": protocol.Protocol,
+			"queries":  protocol.Queries,
+		})
+	}
+	if err := d.Set("protocols", protocols); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package nextdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceNextDNSAnalyticsReasons() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNextDNSAnalyticsReasonsRead,
+		Schema: analyticsSchema(map[string]*schema.Schema{
+			"reasons": {
+				Description: "The number of blocked queries by reason.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "The reason identifier (e.g. blocklist:nextdns-recommended).",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "The reason name.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"queries": {
+							Description: "The number of queries.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func dataSourceNextDNSAnalyticsReasonsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	items, diags := readAnalytics(ctx, d, meta, "reasons", url.Values{})
+	if diags.HasError() {
+		return diags
+	}
+
+	var reasons []map[string]interface{}
+	for _, item := range items {
+		var reason struct {
+			ID      string `json:"id"`
+			Name    string `json:"name"`
+			Queries int    `json:"queries"`
+		}
+		if err := json.Unmarshal(item, &reason); err != nil {
+			return diag.FromErr(err)
+		}
+
+		reasons = append(reasons, map[string]interface{}{
+			"id":      reason.ID,
+			"name":    reason.Name,
+			"queries": reason.Queries,
+		})
+	}
+	if err := d.Set("reasons", reasons); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package nextdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceNextDNSAnalyticsStatus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNextDNSAnalyticsStatusRead,
+		Schema: analyticsSchema(map[string]*schema.Schema{
+			"status": {
+				Description: "The number of queries by resolution status.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"status": {
+							Description: "The resolution status (default, blocked or allowed).",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"queries": {
+							Description: "The number of queries.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func dataSourceNextDNSAnalyticsStatusRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	items, diags := readAnalytics(ctx, d, meta, "status", url.Values{})
+	if diags.HasError() {
+		return diags
+	}
+
+	var status []map[string]interface{}
+	for _, item := range items {
+		var s struct {
+			Status  string `json:"status"`
+			Queries int    `json:"queries"`
+		}
+		if err := json.Unmarshal(item, &s); err != nil {
+			return diag.FromErr(err)
+		}
+
+		status = append(status, map[string]interface{}{
+			"status":  s.Status,
+			"queries": s.Queries,
+		})
+	}
+	if err := d.Set("status", status); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
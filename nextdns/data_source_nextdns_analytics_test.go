@@ -0,0 +1,53 @@
+package nextdns
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNextDNSAnalytics_dataSources(t *testing.T) {
+	s := testAccFakeAPI(t)
+	profileID := s.CreateProfile(map[string]interface{}{"name": "analytics"})
+
+	s.SetAnalytics(profileID, "status", []map[string]interface{}{
+		{"status": "default", "queries": 100},
+		{"status": "blocked", "queries": 20},
+	})
+	s.SetAnalytics(profileID, "domains", []map[string]interface{}{
+		{"domain": "a.example.com", "root": "example.com", "queries": 10},
+		{"domain": "b.example.com", "root": "example.com", "queries": 5},
+		{"domain": "example.org", "root": "example.org", "queries": 1},
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "nextdns_analytics_status" "test" {
+  profile_id = "` + profileID + `"
+  from       = "-7d"
+}
+
+data "nextdns_analytics_domains" "test" {
+  profile_id = "` + profileID + `"
+  status     = "blocked"
+  limit      = 2
+}
+
+data "nextdns_analytics_protocols" "test" {
+  profile_id = "` + profileID + `"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.nextdns_analytics_status.test", "status.#", "2"),
+					resource.TestCheckResourceAttr("data.nextdns_analytics_status.test", "status.1.queries", "20"),
+					resource.TestCheckResourceAttr("data.nextdns_analytics_domains.test", "domains.#", "2"),
+					resource.TestCheckResourceAttr("data.nextdns_analytics_domains.test", "domains.0.root", "example.com"),
+					resource.TestCheckResourceAttr("data.nextdns_analytics_protocols.test", "protocols.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,402 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/amalucelli/nextdns-go/nextdns"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// verdictAllowed is the verdict of a domain allowed by the allow list.
+	verdictAllowed = "allowed"
+	// verdictBlocked is the verdict of a domain blocked by a rule of the profile.
+	verdictBlocked = "blocked"
+	// verdictRewritten is the verdict of a domain answered by a rewrite.
+	verdictRewritten = "rewritten"
+	// verdictDefault is the verdict of a domain matching none of the evaluated rules.
+	verdictDefault = "default"
+)
+
+func dataSourceNextDNSDomainVerdict() *schema.Resource {
+	entry := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        schema.TypeList,
+			Optional:    true,
+			// An empty list can be given as an attribute (e.g. allowlist = []) to replace the entries of the profile.
+			ConfigMode: schema.SchemaConfigModeAttr,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Description:  "The domain, a leading wildcard (e.g. *.example.com) matching its subdomains only.",
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validateDomain,
+					},
+					"active": {
+						Description: "Whether the entry is active.",
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     true,
+					},
+				},
+			},
+		}
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceNextDNSDomainVerdictRead,
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Description:  "The domain to evaluate.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateDomain,
+			},
+			"profile_id": {
+				Description: "The profile whose allow list, deny list, rewrites and blocked TLDs are evaluated. " +
+					"The rules given as arguments replace the ones of the profile, even when empty (e.g. denylist = []). " +
+					"Without it, the evaluation is done offline.",
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"allowlist": entry("The entries of the allow list."),
+			"denylist":  entry("The entries of the deny list."),
+			"rewrite": {
+				Description: "The rewrites.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				ConfigMode:  schema.SchemaConfigModeAttr,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"domain": {
+							Description:  "The domain to rewrite, including its subdomains.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateDomain,
+						},
+						"address": {
+							Description: "The address the domain is answered with.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+					},
+				},
+			},
+			"blocked_tlds": {
+				Description: "The top-level domains blocked by the security settings (e.g. zip).",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"parental_control": {
+				Description: "The services and categories of the parental control, with the domains they block. " +
+					"The API does not expose the domains of the services and categories, so they are only evaluated when given here.",
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "The identifier of the service or category (e.g. tiktok).",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"active": {
+							Description: "Whether the service or category is blocked.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+						},
+						"domains": {
+							Description: "The domains of the service or category, including their subdomains.",
+							Type:        schema.TypeList,
+							Required:    true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validateDomain,
+							},
+						},
+					},
+				},
+			},
+			"verdict": {
+				Description: "The verdict: allowed (by the allow list), blocked, rewritten or default (no rule matches, " +
+					"so the domain is only subject to the blocklists and the threat protection, which are not evaluated).",
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"rule": {
+				Description: "The rule deciding the verdict (e.g. denylist:example.com), empty for the default verdict.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"reason": {
+				Description: "The description of the verdict.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"rewrite_address": {
+				Description: "The address the domain is answered with, when rewritten.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"inactive_rules": {
+				Description: "The inactive rules matching the domain, which are ignored.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNextDNSDomainVerdictRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	domain := normalizeDomain(d.Get("domain").(string))
+	profileID := d.Get("profile_id").(string)
+
+	policy := &domainPolicy{}
+	if len(profileID) > 0 {
+		profile, err := meta.(*providerMeta).profiles.get(ctx, profileID)
+		if err != nil {
+			if isNotFound(err) {
+				return diag.Errorf("profile %q not found", profileID)
+			}
+			return apiErrorDiags(err, "error getting profile")
+		}
+		policy = domainPolicyFromProfile(profile)
+	}
+
+	if v, ok := configuredValue(d, "allowlist"); ok {
+		policy.allowlist = policyEntries(v)
+	}
+	if v, ok := configuredValue(d, "denylist"); ok {
+		policy.denylist = policyEntries(v)
+	}
+	if v, ok := configuredValue(d, "rewrite"); ok {
+		policy.rewrites = nil
+		for _, r := range v.([]interface{}) {
+			rewrite := r.(map[string]interface{})
+			policy.rewrites = append(policy.rewrites, policyRewrite{
+				domain:  normalizeDomain(rewrite["domain"].(string)),
+				address: rewrite["address"].(string),
+			})
+		}
+	}
+	if v, ok := configuredStrings(d, "blocked_tlds"); ok {
+		policy.blockedTlds = v
+	}
+	for _, e := range d.Get("parental_control").([]interface{}) {
+		entry := e.(map[string]interface{})
+		for _, domain := range stringsFromList(entry["domains"]) {
+			policy.parentalControl = append(policy.parentalControl, policyEntry{
+				id:     normalizeDomain(domain),
+				active: entry["active"].(bool),
+				source: entry["id"].(string),
+			})
+		}
+	}
+	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", policy))
+
+	verdict := policy.evaluate(domain)
+
+	d.SetId(profileID + "/" + domain)
+	d.Set("verdict", verdict.verdict)
+	d.Set("rule", verdict.rule)
+	d.Set("reason", verdict.reason)
+	d.Set("rewrite_address", verdict.rewriteAddress)
+	d.Set("inactive_rules", verdict.inactiveRules)
+
+	return nil
+}
+
+// domainPolicy holds the rules of a profile deciding how a domain is resolved.
+type domainPolicy struct {
+	allowlist       []policyEntry
+	denylist        []policyEntry
+	rewrites        []policyRewrite
+	blockedTlds     []string
+	parentalControl []policyEntry
+}
+
+// policyEntry is a domain of a list, matching the domain and its subdomains.
+type policyEntry struct {
+	id     string
+	active bool
+	// source is the service or category declaring the domain, for the parental control entries.
+	source string
+}
+
+// policyRewrite is a rewrite, matching the domain and its subdomains.
+type policyRewrite struct {
+	domain  string
+	address string
+}
+
+// domainVerdict is the result of the evaluation of a domain.
+type domainVerdict struct {
+	verdict        string
+	rule           string
+	reason         string
+	rewriteAddress string
+	inactiveRules  []string
+}
+
+// domainPolicyFromProfile returns the rules of a profile.
+func domainPolicyFromProfile(profile *nextdns.Profile) *domainPolicy {
+	policy := &domainPolicy{}
+
+	for _, e := range profile.Allowlist {
+		policy.allowlist = append(policy.allowlist, policyEntry{id: e.ID, active: e.Active})
+	}
+	for _, e := range profile.Denylist {
+		policy.denylist = append(policy.denylist, policyEntry{id: e.ID, active: e.Active})
+	}
+	for _, r := range profile.Rewrites {
+		policy.rewrites = append(policy.rewrites, policyRewrite{domain: r.Name, address: r.Content})
+	}
+	if profile.Security != nil {
+		for _, tld := range profile.Security.Tlds {
+			policy.blockedTlds = append(policy.blockedTlds, tld.ID)
+		}
+	}
+
+	return policy
+}
+
+// policyEntries returns the entries of a list of the data source.
+func policyEntries(v interface{}) []policyEntry {
+	var entries []policyEntry
+	for _, e := range v.([]interface{}) {
+		entry := e.(map[string]interface{})
+		entries = append(entries, policyEntry{
+			id:     normalizeDomain(entry["id"].(string)),
+			active: entry["active"].(bool),
+		})
+	}
+
+	return entries
+}
+
+// evaluate returns the verdict of the domain, following the matching of NextDNS: an entry matches its domain
+// and its subdomains, the most specific active entry is reported, the allow list wins over every block,
+// and the rewrites apply to the domains that are not blocked.
+func (p *domainPolicy) evaluate(domain string) domainVerdict {
+	var v domainVerdict
+
+	allowed, inactive := matchPolicyEntries(domain, p.allowlist)
+	v.inactiveRules = append(v.inactiveRules, prefixRules("allowlist", inactive)...)
+	denied, inactive := matchPolicyEntries(domain, p.denylist)
+	v.inactiveRules = append(v.inactiveRules, prefixRules("denylist", inactive)...)
+	blockedByService, inactive := matchPolicyEntries(domain, p.parentalControl)
+	for _, e := range inactive {
+		v.inactiveRules = append(v.inactiveRules, fmt.Sprintf("parental_control:%s (%s)", e.source, e.id))
+	}
+
+	var rewrite *policyRewrite
+	for i, r := range p.rewrites {
+		if domainMatches(domain, r.domain) && (rewrite == nil || len(r.domain) > len(rewrite.domain)) {
+			rewrite = &p.rewrites[i]
+		}
+	}
+
+	switch {
+	case allowed != nil:
+		v.verdict = verdictAllowed
+		v.rule = "allowlist:" + allowed.id
+		v.reason = fmt.Sprintf("%q is allowed by %q of the allow list, which wins over the other lists", domain, allowed.id)
+	case denied != nil:
+		v.verdict = verdictBlocked
+		v.rule = "denylist:" + denied.id
+		v.reason = fmt.Sprintf("%q is blocked by %q of the deny list", domain, denied.id)
+		return v
+	case blockedTld(domain, p.blockedTlds) != "":
+		tld := blockedTld(domain, p.blockedTlds)
+		v.verdict = verdictBlocked
+		v.rule = "security_tld:" + tld
+		v.reason = fmt.Sprintf("%q is blocked as the %q top-level domain is blocked by the security settings", domain, tld)
+		return v
+	case blockedByService != nil:
+		v.verdict = verdictBlocked
+		v.rule = "parental_control:" + blockedByService.source
+		v.reason = fmt.Sprintf("%q is blocked by %q of the parental control, as %q", domain, blockedByService.source, blockedByService.id)
+		return v
+	}
+
+	if rewrite != nil {
+		v.verdict = verdictRewritten
+		v.rule = "rewrite:" + rewrite.domain
+		v.reason = fmt.Sprintf("%q is answered with %s by the rewrite of %q", domain, rewrite.address, rewrite.domain)
+		v.rewriteAddress = rewrite.address
+		return v
+	}
+
+	if len(v.verdict) == 0 {
+		v.verdict = verdictDefault
+		v.reason = fmt.Sprintf("%q matches no rule of the profile", domain)
+	}
+
+	return v
+}
+
+// matchPolicyEntries returns the most specific active entry matching the domain if any,
+// and the inactive entries matching it.
+func matchPolicyEntries(domain string, entries []policyEntry) (*policyEntry, []policyEntry) {
+	var match *policyEntry
+	var inactive []policyEntry
+
+	for i, e := range entries {
+		if !domainMatches(domain, e.id) {
+			continue
+		}
+		if !e.active {
+			inactive = append(inactive, e)
+			continue
+		}
+		if match == nil || len(e.id) > len(match.id) {
+			match = &entries[i]
+		}
+	}
+
+	return match, inactive
+}
+
+// domainMatches reports whether the rule matches the domain: a domain matches itself and its subdomains,
+// and a wildcard (e.g. *.example.com) only matches the subdomains.
+func domainMatches(domain string, rule string) bool {
+	rule = normalizeDomain(rule)
+	if strings.HasPrefix(rule, domainWildcard) {
+		return strings.HasSuffix(domain, strings.TrimPrefix(rule, "*"))
+	}
+
+	return domain == rule || strings.HasSuffix(domain, "."+rule)
+}
+
+// blockedTld returns the blocked top-level domain the domain belongs to, if any.
+func blockedTld(domain string, tlds []string) string {
+	for _, tld := range tlds {
+		if domainMatches(domain, tld) {
+			return tld
+		}
+	}
+
+	return ""
+}
+
+// prefixRules returns the rules of the entries of a list (e.g. denylist:example.com).
+func prefixRules(list string, entries []policyEntry) []string {
+	rules := make([]string, 0, len(entries))
+	for _, e := range entries {
+		rules = append(rules, list+":"+e.id)
+	}
+
+	return rules
+}
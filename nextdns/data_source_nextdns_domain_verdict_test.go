@@ -0,0 +1,183 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/amalucelli/terraform-provider-nextdns/internal/fakeapi"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccNextDNSDomainVerdict_dataSource(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProfileConfig() + `
+resource "nextdns_denylist" "test" {
+  profile_id = nextdns_profile.test.id
+
+  domain {
+    id     = "example.com"
+    active = true
+  }
+}
+
+data "nextdns_domain_verdict" "test" {
+  profile_id = nextdns_denylist.test.profile_id
+  domain     = "cdn.example.com"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.nextdns_domain_verdict.test", "verdict", "blocked"),
+					resource.TestCheckResourceAttr("data.nextdns_domain_verdict.test", "rule", "denylist:example.com"),
+				),
+			},
+		},
+	})
+}
+
+func TestDomainPolicyEvaluate(t *testing.T) {
+	policy := &domainPolicy{
+		allowlist: []policyEntry{
+			{id: "good.example.com", active: true},
+			{id: "disabled.example.com", active: false},
+		},
+		denylist: []policyEntry{
+			{id: "example.com", active: true},
+			{id: "*.example.org", active: true},
+			{id: "example.net", active: false},
+		},
+		rewrites: []policyRewrite{
+			{domain: "router.lan", address: "192.168.1.1"},
+			{domain: "good.example.com", address: "10.0.0.1"},
+			{domain: "example.org", address: "10.0.0.2"},
+		},
+		blockedTlds: []string{"zip"},
+		parentalControl: []policyEntry{
+			{id: "tiktok.com", active: true, source: "tiktok"},
+			{id: "fortnite.com", active: false, source: "fortnite"},
+		},
+	}
+
+	tests := []struct {
+		domain   string
+		verdict  string
+		rule     string
+		inactive []string
+	}{
+		{domain: "example.com", verdict: verdictBlocked, rule: "denylist:example.com"},
+		{domain: "cdn.example.com", verdict: verdictBlocked, rule: "denylist:example.com"},
+		{domain: "notexample.com", verdict: verdictDefault},
+		{domain: "api.good.example.com", verdict: verdictRewritten, rule: "rewrite:good.example.com"},
+		{domain: "disabled.example.com", verdict: verdictBlocked, rule: "denylist:example.com", inactive: []string{"allowlist:disabled.example.com"}},
+		{domain: "example.org", verdict: verdictRewritten, rule: "rewrite:example.org"},
+		{domain: "www.example.org", verdict: verdictBlocked, rule: "denylist:*.example.org"},
+		{domain: "example.net", verdict: verdictDefault, inactive: []string{"denylist:example.net"}},
+		{domain: "files.zip", verdict: verdictBlocked, rule: "security_tld:zip"},
+		{domain: "www.tiktok.com", verdict: verdictBlocked, rule: "parental_control:tiktok"},
+		{domain: "fortnite.com", verdict: verdictDefault, inactive: []string{"parental_control:fortnite (fortnite.com)"}},
+		{domain: "nas.router.lan", verdict: verdictRewritten, rule: "rewrite:router.lan"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.domain, func(t *testing.T) {
+			v := policy.evaluate(tt.domain)
+			if v.verdict != tt.verdict || v.rule != tt.rule {
+				t.Errorf("evaluate(%q) = %s (%s), want %s (%s): %s", tt.domain, v.verdict, v.rule, tt.verdict, tt.rule, v.reason)
+			}
+			if !reflect.DeepEqual(v.inactiveRules, tt.inactive) {
+				t.Errorf("evaluate(%q) inactive rules = %v, want %v", tt.domain, v.inactiveRules, tt.inactive)
+			}
+		})
+	}
+}
+
+func TestDomainVerdictReadOffline(t *testing.T) {
+	r := dataSourceNextDNSDomainVerdict()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"domain": "Ads.Example.com",
+		"denylist": []interface{}{
+			map[string]interface{}{"id": "example.com", "active": true},
+		},
+		"allowlist": []interface{}{
+			map[string]interface{}{"id": "ads.example.com", "active": false},
+		},
+	})
+
+	if diags := r.ReadContext(context.Background(), d, nil); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if got := d.Get("verdict"); got != verdictBlocked {
+		t.Errorf("unexpected verdict %q", got)
+	}
+	if got := d.Get("rule"); got != "denylist:example.com" {
+		t.Errorf("unexpected rule %q", got)
+	}
+	if got := d.Get("inactive_rules.0"); got != "allowlist:ads.example.com" {
+		t.Errorf("unexpected inactive rule %q", got)
+	}
+}
+
+func TestDomainVerdictRead_emptyList(t *testing.T) {
+	s := fakeapi.New()
+	defer s.Close()
+
+	meta, err := newProviderMeta(&clientConfig{apiKey: "test", apiURL: s.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profileID := s.CreateProfile(map[string]interface{}{
+		"name":     "test",
+		"denylist": []interface{}{map[string]interface{}{"id": "example.com", "active": true}},
+		"rewrites": []interface{}{map[string]interface{}{"name": "example.com", "content": "192.0.2.1"}},
+	})
+
+	r := dataSourceNextDNSDomainVerdict()
+	block := schema.InternalMap(r.Schema).CoreConfigSchema()
+
+	tests := []struct {
+		name        string
+		arguments   string
+		wantVerdict string
+	}{
+		{name: "profile", wantVerdict: verdictBlocked},
+		{name: "empty deny list", arguments: `, "denylist": []`, wantVerdict: verdictRewritten},
+		{name: "empty deny list and rewrites", arguments: `, "denylist": [], "rewrite": []`, wantVerdict: verdictDefault},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := ctyjson.Unmarshal([]byte(fmt.Sprintf(`{"domain": "www.example.com", "profile_id": %q%s}`,
+				profileID, tt.arguments)), block.ImpliedType())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigShimmed(config, block), meta)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// As the gRPC provider server does, the raw configuration is kept along the diff.
+			diff.RawConfig = config
+
+			state, diags := r.ReadDataApply(context.Background(), diff, meta)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if got := state.Attributes["verdict"]; got != tt.wantVerdict {
+				t.Errorf("verdict = %q, want %q", got, tt.wantVerdict)
+			}
+		})
+	}
+}
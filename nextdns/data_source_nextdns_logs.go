@@ -0,0 +1,234 @@
+package nextdns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// logsMinPageSize is the minimum number of entries returned by a single logs request.
+	logsMinPageSize = 10
+	// logsMaxPageSize is the maximum number of entries returned by a single logs request.
+	logsMaxPageSize = 1000
+)
+
+// logEntry represents an entry of the query logs.
+type logEntry struct {
+	Timestamp string `json:"timestamp"`
+	Domain    string `json:"domain"`
+	Root      string `json:"root"`
+	Tracker   string `json:"tracker"`
+	Encrypted bool   `json:"encrypted"`
+	Protocol  string `json:"protocol"`
+	ClientIP  string `json:"clientIp"`
+	Client    string `json:"client"`
+	Status    string `json:"status"`
+	Device    *struct {
+		ID    string `json:"id"`
+		Name  string `json:"name"`
+		Model string `json:"model"`
+	} `json:"device"`
+	Reasons []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"reasons"`
+}
+
+func dataSourceNextDNSLogs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNextDNSLogsRead,
+		Schema: map[string]*schema.Schema{
+			"profile_id": {
+				Description: "The profile identifier to target the data source.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"from": {
+				Description: "Start of the time range, as an ISO 8601 date, a UNIX timestamp or a relative date (e.g. -1h).",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"to": {
+				Description: "End of the time range, as an ISO 8601 date, a UNIX timestamp or a relative date (e.g. now).",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"status": {
+				Description:  "Only return the queries with this resolution status (default, error, blocked or allowed).",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"default", "error", "blocked", "allowed"}, false),
+			},
+			"device": {
+				Description: "Device identifier to filter the queries by.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"search": {
+				Description: "Only return the queries whose domain contains this value.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"limit": {
+				Description:  "Maximum number of entries to return, the most recent entries first.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"entries": {
+				Description: "The entries of the query logs.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"timestamp": {
+							Description: "The time of the query.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"domain": {
+							Description: "The queried domain.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"root": {
+							Description: "The root domain of the queried domain.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"tracker": {
+							Description: "The tracker the domain belongs to, if any.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"status": {
+							Description: "The resolution status (default, error, blocked or allowed).",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"reasons": {
+							Description: "The identifiers of the reasons the query was blocked or allowed.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"device_id": {
+							Description: "The identifier of the device.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"device_name": {
+							Description: "The name of the device.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"protocol": {
+							Description: "The protocol of the query (e.g. DNS-over-HTTPS).",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"encrypted": {
+							Description: "Whether the query was encrypted.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"client_ip": {
+							Description: "The IP of the client.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"client": {
+							Description: "The client that sent the query, if known.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNextDNSLogsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	api := meta.(*providerMeta).api
+	profileID := d.Get("profile_id").(string)
+	limit := d.Get("limit").(int)
+
+	query := url.Values{}
+	for _, key := range []string{"from", "to", "status", "device", "search"} {
+		if v, ok := d.GetOk(key); ok {
+			query.Set(key, v.(string))
+		}
+	}
+
+	pageSize := limit
+	if pageSize < logsMinPageSize {
+		pageSize = logsMinPageSize
+	}
+	if pageSize > logsMaxPageSize {
+		pageSize = logsMaxPageSize
+	}
+	query.Set("limit", strconv.Itoa(pageSize))
+	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: logs %+v", query))
+
+	items, err := api.list(ctx, profilePath(profileID, "logs"), query, limit)
+	if err != nil {
+		if isNotFound(err) {
+			return diag.Errorf("profile %q not found", profileID)
+		}
+		return apiErrorDiags(err, "error getting logs")
+	}
+
+	entries := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		e := &logEntry{}
+		if err := json.Unmarshal(item, e); err != nil {
+			return diag.FromErr(err)
+		}
+		entries = append(entries, flattenLogEntry(e))
+	}
+
+	d.SetId(profileID)
+	if err := d.Set("entries", entries); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func flattenLogEntry(e *logEntry) map[string]interface{} {
+	reasons := make([]string, 0, len(e.Reasons))
+	for _, r := range e.Reasons {
+		reasons = append(reasons, r.ID)
+	}
+
+	entry := map[string]interface{}{
+		"timestamp": e.Timestamp,
+		"domain":    e.Domain,
+		"root":      e.Root,
+		"tracker":   e.Tracker,
+		"status":    e.Status,
+		"reasons":   reasons,
+		"protocol":  e.Protocol,
+		"encrypted": e.Encrypted,
+		"client_ip": e.ClientIP,
+		"client":    e.Client,
+	}
+	if e.Device != nil {
+		entry["device_id"] = e.Device.ID
+		entry["device_name"] = e.Device.Name
+	}
+
+	return entry
+}
@@ -0,0 +1,55 @@
+package nextdns
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNextDNSLogs_dataSource(t *testing.T) {
+	s := testAccFakeAPI(t)
+	profileID := s.CreateProfile(map[string]interface{}{"name": "logs"})
+
+	s.SetLogs(profileID, []map[string]interface{}{
+		{
+			"timestamp": "2024-01-01T10:00:00.000Z",
+			"domain":    "ads.example.com",
+			"root":      "example.com",
+			"status":    "blocked",
+			"reasons":   []interface{}{map[string]interface{}{"id": "blocklist:nextdns-recommended", "name": "NextDNS Ads & Trackers Blocklist"}},
+			"protocol":  "DNS-over-HTTPS",
+			"clientIp":  "192.0.2.1",
+			"device":    map[string]interface{}{"id": "ABCDE", "name": "laptop"},
+		},
+		{
+			"timestamp": "2024-01-01T09:00:00.000Z",
+			"domain":    "www.example.org",
+			"root":      "example.org",
+			"status":    "default",
+			"protocol":  "DNS-over-TLS",
+			"clientIp":  "192.0.2.2",
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "nextdns_logs" "test" {
+  profile_id = "` + profileID + `"
+  status     = "blocked"
+  search     = "example"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.nextdns_logs.test", "entries.#", "1"),
+					resource.TestCheckResourceAttr("data.nextdns_logs.test", "entries.0.domain", "ads.example.com"),
+					resource.TestCheckResourceAttr("data.nextdns_logs.test", "entries.0.reasons.0", "blocklist:nextdns-recommended"),
+					resource.TestCheckResourceAttr("data.nextdns_logs.test", "entries.0.device_name", "laptop"),
+					resource.TestCheckResourceAttr("data.nextdns_logs.test", "entries.0.client_ip", "192.0.2.1"),
+				),
+			},
+		},
+	})
+}
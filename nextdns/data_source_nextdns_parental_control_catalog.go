@@ -0,0 +1,75 @@
+package nextdns
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceNextDNSParentalControlCatalog() *schema.Resource {
+	entry := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The identifier, as used by the nextdns_parental_control resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"name": {
+				Description: "The display name.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"description": {
+				Description: "The description.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceNextDNSParentalControlCatalogRead,
+		Schema: map[string]*schema.Schema{
+			"services": {
+				Description: "The websites, apps and games known by the provider.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        entry,
+			},
+			"categories": {
+				Description: "The categories of websites and apps known by the provider.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        entry,
+			},
+		},
+	}
+}
+
+func dataSourceNextDNSParentalControlCatalogRead(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId(strconv.Itoa(schema.HashString(string(parentalControlCatalogJSON))))
+	if err := d.Set("services", flattenCatalogEntries(catalog.Services)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("categories", flattenCatalogEntries(catalog.Categories)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// flattenCatalogEntries returns the blocks of the entries of the catalog.
+func flattenCatalogEntries(entries []catalogEntry) []map[string]interface{} {
+	blocks := make([]map[string]interface{}, 0, len(entries))
+	for _, e := range entries {
+		blocks = append(blocks, map[string]interface{}{
+			"id":          e.ID,
+			"name":        e.Name,
+			"description": e.Description,
+		})
+	}
+
+	return blocks
+}
@@ -0,0 +1,31 @@
+package nextdns
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNextDNSParentalControlCatalog_dataSource(t *testing.T) {
+	testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "nextdns_parental_control_catalog" "test" {}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.nextdns_parental_control_catalog.test", "services.#", strconv.Itoa(len(catalog.Services))),
+					resource.TestCheckResourceAttr("data.nextdns_parental_control_catalog.test", "categories.#", strconv.Itoa(len(catalog.Categories))),
+					resource.TestCheckTypeSetElemNestedAttrs("data.nextdns_parental_control_catalog.test", "services.*", map[string]string{
+						"id":   "tiktok",
+						"name": "TikTok",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,73 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceNextDNSProfile() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNextDNSProfileRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The exact name of the profile to look up.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"profile_id": {
+				Description: "The profile identifier.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"fingerprint": {
+				Description: "The profile fingerprint.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"role": {
+				Description: "The role of the account on the profile (e.g. owner).",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceNextDNSProfileRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	api := meta.(*providerMeta).api
+	name := d.Get("name").(string)
+
+	profiles, err := api.listProfiles(ctx)
+	if err != nil {
+		return apiErrorDiags(err, "error listing profiles")
+	}
+	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", profiles))
+
+	var found []*apiProfile
+	for _, p := range profiles {
+		if p.Name == name {
+			found = append(found, p)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return diag.Errorf("no profile found with name %q", name)
+	case 1:
+	default:
+		return diag.Errorf("%d profiles found with name %q, the name must be unique", len(found), name)
+	}
+
+	profile := found[0]
+
+	d.SetId(profile.ID)
+	d.Set("profile_id", profile.ID)
+	d.Set("fingerprint", profile.Fingerprint)
+	d.Set("role", profile.Role)
+
+	return nil
+}
@@ -0,0 +1,297 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// policyConflictAllowDeny is the kind of the conflicts between the allow list and the deny list.
+	policyConflictAllowDeny = "allowlist_denylist"
+	// policyConflictDenyRewrite is the kind of the conflicts between the deny list and the rewrites.
+	policyConflictDenyRewrite = "denylist_rewrite"
+	// maxReportedConflicts is the number of conflicts detailed by the diagnostic of the data source.
+	maxReportedConflicts = 20
+)
+
+func dataSourceNextDNSProfilePolicyCheck() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNextDNSProfilePolicyCheckRead,
+		Schema: map[string]*schema.Schema{
+			"profile_id": {
+				Description: "The profile whose active domains are checked. The lists given as arguments replace the ones of the profile.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"allowlist": {
+				Description: "The active domains of the allow list to check, replacing the ones of the profile even when empty " +
+					"(e.g. [for d in nextdns_allowlist.this.domain : d.id if d.active && !contains(nextdns_allowlist.this.expired_domains, d.id)]).",
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"denylist": {
+				Description: "The active domains of the deny list to check, replacing the ones of the profile even when empty " +
+					"(e.g. [for d in nextdns_denylist.this.domain : d.id if d.active && !contains(nextdns_denylist.this.expired_domains, d.id)]).",
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"rewrites": {
+				Description: "The domains of the rewrites to check, replacing the ones of the profile even when empty " +
+					"(e.g. the planned domains of a nextdns_rewrite resource).",
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"fail_on_conflict": {
+				Description: "Whether the conflicts fail the plan, instead of being reported as warnings.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"has_conflicts": {
+				Description: "Whether conflicts were found.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"conflicts": {
+				Description: "The domains of the allow list overlapping the deny list, which the allow list silently wins over, " +
+					"and the domains of the deny list overlapping the rewrites, which the deny list blocks. " +
+					"A domain overlaps another when it is the same domain, or a parent or a subdomain of it.",
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"kind": {
+							Description: "The lists in conflict: allowlist_denylist or denylist_rewrite.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"domain": {
+							Description: "The domain of the first list (the allow list, or the deny list).",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"conflicting_domain": {
+							Description: "The domain of the second list (the deny list, or the rewrites).",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"exact": {
+							Description: "Whether both domains are the same, otherwise one is a subdomain of the other.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"message": {
+							Description: "The description of the conflict.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNextDNSProfilePolicyCheckRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	profileID := d.Get("profile_id").(string)
+
+	var allowlist, denylist, rewrites []string
+	if len(profileID) > 0 {
+		profile, err := meta.(*providerMeta).profiles.get(ctx, profileID)
+		if err != nil {
+			if isNotFound(err) {
+				return diag.Errorf("profile %q not found", profileID)
+			}
+			return apiErrorDiags(err, "error getting profile")
+		}
+
+		for _, e := range profile.Allowlist {
+			if e.Active {
+				allowlist = append(allowlist, e.ID)
+			}
+		}
+		for _, e := range profile.Denylist {
+			if e.Active {
+				denylist = append(denylist, e.ID)
+			}
+		}
+		for _, r := range profile.Rewrites {
+			rewrites = append(rewrites, r.Name)
+		}
+	}
+
+	if v, ok := configuredStrings(d, "allowlist"); ok {
+		allowlist = v
+	}
+	if v, ok := configuredStrings(d, "denylist"); ok {
+		denylist = v
+	}
+	if v, ok := configuredStrings(d, "rewrites"); ok {
+		rewrites = v
+	}
+
+	conflicts := findPolicyConflicts(policyConflictAllowDeny, "allow list", allowlist, "deny list", denylist)
+	conflicts = append(conflicts, findPolicyConflicts(policyConflictDenyRewrite, "deny list", denylist, "rewrites", rewrites)...)
+	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", conflicts))
+
+	id := profileID
+	if len(id) == 0 {
+		id = strconv.Itoa(schema.HashString(strings.Join(allowlist, ",") + "|" + strings.Join(denylist, ",") + "|" + strings.Join(rewrites, ",")))
+	}
+	d.SetId(id)
+	d.Set("has_conflicts", len(conflicts) > 0)
+	if err := d.Set("conflicts", flattenPolicyConflicts(conflicts)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	severity := diag.Warning
+	if d.Get("fail_on_conflict").(bool) {
+		severity = diag.Error
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: severity,
+			Summary:  fmt.Sprintf("%d conflicting domains found", len(conflicts)),
+			Detail:   policyConflictsDetail(conflicts),
+		},
+	}
+}
+
+// policyConflict is a domain of a list overlapping a domain of another list.
+type policyConflict struct {
+	kind              string
+	domain            string
+	conflictingDomain string
+	exact             bool
+	message           string
+}
+
+// findPolicyConflicts returns the conflicts between the domains of two lists, which are the same domains,
+// or a domain and one of its subdomains. A leading wildcard (e.g. *.example.com) is matched as its domain.
+func findPolicyConflicts(kind string, name string, domains []string, otherName string, others []string) []policyConflict {
+	index := func(values []string) map[string][]string {
+		indexed := make(map[string][]string, len(values))
+		for _, v := range values {
+			domain := normalizeDomain(v)
+			base := strings.TrimPrefix(domain, domainWildcard)
+			indexed[base] = append(indexed[base], domain)
+		}
+		return indexed
+	}
+	first := index(domains)
+	second := index(others)
+
+	var conflicts []policyConflict
+	add := func(domain string, other string) {
+		c := policyConflict{
+			kind:              kind,
+			domain:            domain,
+			conflictingDomain: other,
+			exact:             domain == other,
+		}
+		if c.exact {
+			c.message = fmt.Sprintf("%q is in both the %s and the %s", domain, name, otherName)
+		} else {
+			c.message = fmt.Sprintf("%q of the %s overlaps %q of the %s", domain, name, other, otherName)
+		}
+		conflicts = append(conflicts, c)
+	}
+
+	// The domains of the second list matching a domain of the first list, or one of its parents.
+	for base, values := range first {
+		for _, parent := range parentDomains(base) {
+			for _, domain := range values {
+				for _, other := range second[parent] {
+					add(domain, other)
+				}
+			}
+		}
+	}
+	// The domains of the second list which are parents of a domain of the first list were found above,
+	// the ones which are subdomains are found from the second list.
+	for base, values := range second {
+		for _, parent := range parentDomains(base)[1:] {
+			for _, other := range values {
+				for _, domain := range first[parent] {
+					add(domain, other)
+				}
+			}
+		}
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].domain != conflicts[j].domain {
+			return conflicts[i].domain < conflicts[j].domain
+		}
+		return conflicts[i].conflictingDomain < conflicts[j].conflictingDomain
+	})
+
+	return conflicts
+}
+
+// parentDomains returns the domain followed by its parents (e.g. a.example.com, example.com, com).
+func parentDomains(domain string) []string {
+	parents := []string{domain}
+	for {
+		_, parent, ok := strings.Cut(domain, ".")
+		if !ok || len(parent) == 0 {
+			return parents
+		}
+		parents = append(parents, parent)
+		domain = parent
+	}
+}
+
+// flattenPolicyConflicts returns the conflicts blocks of the conflicts.
+func flattenPolicyConflicts(conflicts []policyConflict) []map[string]interface{} {
+	blocks := make([]map[string]interface{}, 0, len(conflicts))
+	for _, c := range conflicts {
+		blocks = append(blocks, map[string]interface{}{
+			"kind":               c.kind,
+			"domain":             c.domain,
+			"conflicting_domain": c.conflictingDomain,
+			"exact":              c.exact,
+			"message":            c.message,
+		})
+	}
+
+	return blocks
+}
+
+// policyConflictsDetail returns the description of the conflicts, limited to the first ones.
+func policyConflictsDetail(conflicts []policyConflict) string {
+	var detail strings.Builder
+	for i, c := range conflicts {
+		if i == maxReportedConflicts {
+			fmt.Fprintf(&detail, "\n... and %d more, see the conflicts attribute.", len(conflicts)-maxReportedConflicts)
+			break
+		}
+		if i > 0 {
+			detail.WriteString("\n")
+		}
+		detail.WriteString(c.message)
+	}
+
+	return detail.String()
+}
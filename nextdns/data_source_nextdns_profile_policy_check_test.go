@@ -0,0 +1,193 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/amalucelli/terraform-provider-nextdns/internal/fakeapi"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccNextDNSProfilePolicyCheck_dataSource(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNextDNSProfilePolicyCheckConfig(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.nextdns_profile_policy_check.test", "has_conflicts", "true"),
+					resource.TestCheckResourceAttr("data.nextdns_profile_policy_check.test", "conflicts.#", "2"),
+					resource.TestCheckResourceAttr("data.nextdns_profile_policy_check.test", "conflicts.0.domain", "example.com"),
+					resource.TestCheckResourceAttr("data.nextdns_profile_policy_check.test", "conflicts.0.exact", "true"),
+					resource.TestCheckResourceAttr("data.nextdns_profile_policy_check.test", "conflicts.1.conflicting_domain", "ads.example.org"),
+				),
+			},
+			{
+				Config:      testAccNextDNSProfilePolicyCheckConfig(true),
+				ExpectError: regexp.MustCompile(`conflicting domains found`),
+			},
+		},
+	})
+}
+
+func testAccNextDNSProfilePolicyCheckConfig(failOnConflict bool) string {
+	return testAccProfileConfig() + fmt.Sprintf(`
+resource "nextdns_allowlist" "test" {
+  profile_id = nextdns_profile.test.id
+
+  domain {
+    id     = "example.com"
+    active = true
+  }
+}
+
+resource "nextdns_denylist" "test" {
+  profile_id = nextdns_profile.test.id
+
+  domain {
+    id     = "example.com"
+    active = true
+  }
+
+  domain {
+    id     = "ads.example.org"
+    active = true
+  }
+
+  # Inactive domains do not conflict with the allow list.
+  domain {
+    id     = "www.example.com"
+    active = false
+  }
+}
+
+data "nextdns_profile_policy_check" "test" {
+  allowlist        = [for d in nextdns_allowlist.test.domain : d.id if d.active]
+  denylist         = [for d in nextdns_denylist.test.domain : d.id if d.active]
+  rewrites         = ["example.org"]
+  fail_on_conflict = %t
+}
+`, failOnConflict)
+}
+
+func TestFindPolicyConflicts(t *testing.T) {
+	conflicts := findPolicyConflicts(policyConflictAllowDeny, "allow list",
+		[]string{"example.com", "*.example.org", "sub.example.net", "other.com"},
+		"deny list",
+		[]string{"Example.com", "ads.example.org", "example.net", "notexample.com"},
+	)
+
+	var got []string
+	for _, c := range conflicts {
+		got = append(got, c.message)
+	}
+
+	want := []string{
+		`"*.example.org" of the allow list overlaps "ads.example.org" of the deny list`,
+		`"example.com" is in both the allow list and the deny list`,
+		`"sub.example.net" of the allow list overlaps "example.net" of the deny list`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findPolicyConflicts() = %q, want %q", got, want)
+	}
+}
+
+func TestProfilePolicyCheckRead(t *testing.T) {
+	r := dataSourceNextDNSProfilePolicyCheck()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"denylist": []interface{}{"example.com"},
+		"rewrites": []interface{}{"www.example.com"},
+	})
+
+	diags := r.ReadContext(context.Background(), d, nil)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a warning, got %v", diags)
+	}
+	if got := d.Get("conflicts.0.kind"); got != policyConflictDenyRewrite {
+		t.Errorf("unexpected kind of conflict %q", got)
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"allowlist":        []interface{}{"example.com"},
+		"denylist":         []interface{}{"example.org"},
+		"fail_on_conflict": true,
+	})
+
+	if diags := r.ReadContext(context.Background(), d, nil); len(diags) > 0 {
+		t.Errorf("unexpected diagnostics %v", diags)
+	}
+	if d.Get("has_conflicts").(bool) {
+		t.Error("unexpected conflicts")
+	}
+}
+
+func TestProfilePolicyCheckRead_emptyList(t *testing.T) {
+	s := fakeapi.New()
+	defer s.Close()
+
+	meta, err := newProviderMeta(&clientConfig{apiKey: "test", apiURL: s.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profileID := s.CreateProfile(map[string]interface{}{
+		"name":     "test",
+		"denylist": []interface{}{map[string]interface{}{"id": "example.com", "active": true}},
+		"rewrites": []interface{}{map[string]interface{}{"name": "example.com", "content": "192.0.2.1"}},
+	})
+
+	r := dataSourceNextDNSProfilePolicyCheck()
+	block := schema.InternalMap(r.Schema).CoreConfigSchema()
+
+	tests := []struct {
+		name          string
+		config        map[string]cty.Value
+		wantConflicts bool
+	}{
+		{name: "profile", wantConflicts: true},
+		{name: "empty", config: map[string]cty.Value{"denylist": cty.SetValEmpty(cty.String)}, wantConflicts: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Every attribute is in the configuration sent by Terraform, the ones not set being null.
+			values := map[string]cty.Value{"profile_id": cty.StringVal(profileID)}
+			for name, attributeType := range block.ImpliedType().AttributeTypes() {
+				if _, ok := values[name]; ok {
+					continue
+				}
+				values[name] = cty.NullVal(attributeType)
+				if v, ok := tt.config[name]; ok {
+					values[name] = v
+				}
+			}
+
+			config := terraform.NewResourceConfigShimmed(cty.ObjectVal(values), block)
+			diff, err := r.Diff(context.Background(), nil, config, meta)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// As the gRPC provider server does, the raw configuration is kept along the diff.
+			diff.RawConfig = cty.ObjectVal(values)
+
+			state, diags := r.ReadDataApply(context.Background(), diff, meta)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if got := state.Attributes["has_conflicts"] == "true"; got != tt.wantConflicts {
+				t.Errorf("has_conflicts = %t, want %t", got, tt.wantConflicts)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceNextDNSProfiles() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNextDNSProfilesRead,
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Description:  "Regular expression to filter the profiles by name.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"profiles": {
+				Description: "The profiles of the account.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "The profile identifier.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "The profile name.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"fingerprint": {
+							Description: "The profile fingerprint.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"role": {
+							Description: "The role of the account on the profile (e.g. owner).",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNextDNSProfilesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	api := meta.(*providerMeta).api
+
+	profiles, err := api.listProfiles(ctx)
+	if err != nil {
+		return apiErrorDiags(err, "error listing profiles")
+	}
+	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", profiles))
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+
+	var ids []string
+	var values []map[string]interface{}
+
+	for _, p := range profiles {
+		if nameRegex != nil && !nameRegex.MatchString(p.Name) {
+			continue
+		}
+
+		ids = append(ids, p.ID)
+		values = append(values, map[string]interface{}{
+			"id":          p.ID,
+			"name":        p.Name,
+			"fingerprint": p.Fingerprint,
+			"role":        p.Role,
+		})
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(strings.Join(ids, ","))))
+	if err := d.Set("profiles", values); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
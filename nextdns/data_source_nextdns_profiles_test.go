@@ -0,0 +1,39 @@
+package nextdns
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNextDNSProfiles_dataSources(t *testing.T) {
+	s := testAccFakeAPI(t)
+	s.CreateProfile(map[string]interface{}{"name": "other-team"})
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProfileConfig() + `
+data "nextdns_profiles" "test" {
+  name_regex = "^terraform-"
+
+  depends_on = [nextdns_profile.test]
+}
+
+data "nextdns_profile" "test" {
+  name = "other-team"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.nextdns_profiles.test", "profiles.#", "1"),
+					resource.TestCheckResourceAttrPair("data.nextdns_profiles.test", "profiles.0.id", "nextdns_profile.test", "id"),
+					resource.TestCheckResourceAttr("data.nextdns_profiles.test", "profiles.0.role", "owner"),
+					resource.TestCheckResourceAttrSet("data.nextdns_profile.test", "profile_id"),
+					resource.TestCheckResourceAttrSet("data.nextdns_profile.test", "fingerprint"),
+				),
+			},
+		},
+	})
+}
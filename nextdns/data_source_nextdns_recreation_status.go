@@ -0,0 +1,209 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amalucelli/nextdns-go/nextdns"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceNextDNSRecreationStatus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNextDNSRecreationStatusRead,
+		Schema: map[string]*schema.Schema{
+			"profile_id": {
+				Description: "The profile identifier to target the resource.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"at": {
+				Description:  "The time to evaluate the recreation at, in RFC 3339 format (e.g. 2024-01-02T15:04:05Z). Defaults to the current time.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"timezone": {
+				Description: "The time zone of the recreation windows.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"active": {
+				Description: "Whether the recreation time is active.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"current_window_end": {
+				Description: "When the current recreation window ends, in RFC 3339 format. Empty when the recreation time is not active.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"next_window_start": {
+				Description: "When the next recreation window starts, in RFC 3339 format. Empty when no window is scheduled.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"next_window_end": {
+				Description: "When the next recreation window ends, in RFC 3339 format. Empty when no window is scheduled.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceNextDNSRecreationStatusRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	profiles := meta.(*providerMeta).profiles
+	profileID := d.Get("profile_id").(string)
+
+	at := time.Now()
+	if v, ok := d.GetOk("at"); ok {
+		var err error
+		if at, err = time.Parse(time.RFC3339, v.(string)); err != nil {
+			return diag.FromErr(fmt.Errorf("error parsing at: %w", err))
+		}
+	}
+
+	profile, err := profiles.get(ctx, profileID)
+	if err != nil {
+		if isNotFound(err) {
+			return diag.Errorf("profile %q not found", profileID)
+		}
+		return apiErrorDiags(err, "error getting parental control settings")
+	}
+
+	recreation := &nextdns.ParentalControlRecreation{}
+	if profile.ParentalControl != nil && profile.ParentalControl.Recreation != nil {
+		recreation = profile.ParentalControl.Recreation
+	}
+	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", recreation))
+
+	status, err := evaluateRecreation(recreation, at)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error evaluating recreation: %w", err))
+	}
+
+	d.SetId(profileID + "/" + strconv.FormatInt(at.Unix(), 10))
+	d.Set("timezone", recreation.Timezone)
+	d.Set("active", status.active)
+	d.Set("current_window_end", formatRecreationTime(status.currentEnd))
+	d.Set("next_window_start", formatRecreationTime(status.next.start))
+	d.Set("next_window_end", formatRecreationTime(status.next.end))
+
+	return nil
+}
+
+// recreationWindow is a recreation window at a given date.
+type recreationWindow struct {
+	start time.Time
+	end   time.Time
+}
+
+// recreationStatus is the state of the recreation at a given time.
+type recreationStatus struct {
+	active     bool
+	currentEnd time.Time
+	next       recreationWindow
+}
+
+// evaluateRecreation returns the state of the recreation at the given time, the windows being in the time zone
+// of the recreation. A window whose end is before its start ends on the next day.
+func evaluateRecreation(recreation *nextdns.ParentalControlRecreation, at time.Time) (recreationStatus, error) {
+	var status recreationStatus
+	if recreation.Times == nil {
+		return status, nil
+	}
+
+	location := time.UTC
+	if len(recreation.Timezone) > 0 {
+		var err error
+		if location, err = time.LoadLocation(recreation.Timezone); err != nil {
+			return status, err
+		}
+	}
+	at = at.In(location)
+
+	// The window of the previous day may still be active, and the next window starts within a week.
+	for offset := -1; offset <= 7; offset++ {
+		date := at.AddDate(0, 0, offset)
+
+		window, ok, err := recreationWindowOn(recreation.Times, date)
+		if err != nil {
+			return status, err
+		}
+		if !ok {
+			continue
+		}
+
+		if !at.Before(window.start) && at.Before(window.end) {
+			status.active = true
+			if window.end.After(status.currentEnd) {
+				status.currentEnd = window.end
+			}
+		}
+		if window.start.After(at) && (status.next.start.IsZero() || window.start.Before(status.next.start)) {
+			status.next = window
+		}
+	}
+
+	return status, nil
+}
+
+// recreationWindowOn returns the recreation window starting on the day of the date, if any.
+func recreationWindowOn(times *nextdns.ParentalControlRecreationTimes, date time.Time) (recreationWindow, bool, error) {
+	day := weekdays[(int(date.Weekday())+6)%len(weekdays)]
+
+	interval := *recreationInterval(times, day)
+	if interval == nil {
+		return recreationWindow{}, false, nil
+	}
+
+	start, err := recreationTimeOn(date, interval.Start)
+	if err != nil {
+		return recreationWindow{}, false, err
+	}
+	end, err := recreationTimeOn(date, interval.End)
+	if err != nil {
+		return recreationWindow{}, false, err
+	}
+	if !end.After(start) {
+		end, _ = recreationTimeOn(date.AddDate(0, 0, 1), interval.End)
+	}
+
+	return recreationWindow{start: start, end: end}, true, nil
+}
+
+// recreationTimeOn returns the time of the day (e.g. 18:30:00) at the date, in the location of the date.
+func recreationTimeOn(date time.Time, clock string) (time.Time, error) {
+	parts := strings.Split(clock, ":")
+	if len(parts) < 2 {
+		// nolint:goerr113
+		return time.Time{}, fmt.Errorf("unexpected format of time %q, expected HH:MM:SS", clock)
+	}
+
+	values := make([]int, 3)
+	for i := 0; i < len(parts) && i < len(values); i++ {
+		v, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unexpected format of time %q, expected HH:MM:SS: %w", clock, err)
+		}
+		values[i] = v
+	}
+
+	return time.Date(date.Year(), date.Month(), date.Day(), values[0], values[1], values[2], 0, date.Location()), nil
+}
+
+// formatRecreationTime returns the time in RFC 3339 format, or an empty string for the zero time.
+func formatRecreationTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
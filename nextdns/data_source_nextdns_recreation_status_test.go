@@ -0,0 +1,163 @@
+package nextdns
+
+import (
+	"testing"
+	"time"
+
+	"github.com/amalucelli/nextdns-go/nextdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNextDNSRecreationStatus_dataSource(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProfileConfig() + `
+resource "nextdns_parental_control" "test" {
+  profile_id = nextdns_profile.test.id
+
+  recreation {
+    timezone = "America/New_York"
+
+    schedule {
+      days             = ["saturday"]
+      start            = "20:00:00"
+      end              = "01:00:00"
+      crosses_midnight = true
+    }
+  }
+}
+
+data "nextdns_recreation_status" "test" {
+  profile_id = nextdns_parental_control.test.profile_id
+  at         = "2024-01-07T05:30:00Z"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.nextdns_recreation_status.test", "timezone", "America/New_York"),
+					resource.TestCheckResourceAttr("data.nextdns_recreation_status.test", "active", "true"),
+					resource.TestCheckResourceAttr("data.nextdns_recreation_status.test", "current_window_end", "2024-01-07T01:00:00-05:00"),
+					resource.TestCheckResourceAttr("data.nextdns_recreation_status.test", "next_window_start", "2024-01-13T20:00:00-05:00"),
+					resource.TestCheckResourceAttr("data.nextdns_recreation_status.test", "next_window_end", "2024-01-14T01:00:00-05:00"),
+				),
+			},
+		},
+	})
+}
+
+func TestEvaluateRecreation(t *testing.T) {
+	recreation := &nextdns.ParentalControlRecreation{
+		Timezone: "America/New_York",
+		Times: &nextdns.ParentalControlRecreationTimes{
+			Monday:   &nextdns.ParentalControlRecreationInterval{Start: "16:00:00", End: "18:00:00"},
+			Friday:   &nextdns.ParentalControlRecreationInterval{Start: "20:00:00", End: "01:00:00"},
+			Saturday: &nextdns.ParentalControlRecreationInterval{Start: "20:00:00", End: "01:00:00"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		at         string
+		active     bool
+		currentEnd string
+		nextStart  string
+		nextEnd    string
+	}{
+		{
+			name:      "before a window",
+			at:        "2024-01-08T12:00:00-05:00",
+			nextStart: "2024-01-08T16:00:00-05:00",
+			nextEnd:   "2024-01-08T18:00:00-05:00",
+		},
+		{
+			name:       "within a window",
+			at:         "2024-01-08T16:00:00-05:00",
+			active:     true,
+			currentEnd: "2024-01-08T18:00:00-05:00",
+			nextStart:  "2024-01-12T20:00:00-05:00",
+			nextEnd:    "2024-01-13T01:00:00-05:00",
+		},
+		{
+			name:      "at the end of a window",
+			at:        "2024-01-08T18:00:00-05:00",
+			nextStart: "2024-01-12T20:00:00-05:00",
+			nextEnd:   "2024-01-13T01:00:00-05:00",
+		},
+		{
+			name:       "after midnight of a window crossing midnight",
+			at:         "2024-01-07T05:30:00Z",
+			active:     true,
+			currentEnd: "2024-01-07T01:00:00-05:00",
+			nextStart:  "2024-01-08T16:00:00-05:00",
+			nextEnd:    "2024-01-08T18:00:00-05:00",
+		},
+		{
+			name:       "between consecutive windows crossing midnight",
+			at:         "2024-01-12T23:00:00-05:00",
+			active:     true,
+			currentEnd: "2024-01-13T01:00:00-05:00",
+			nextStart:  "2024-01-13T20:00:00-05:00",
+			nextEnd:    "2024-01-14T01:00:00-05:00",
+		},
+		{
+			name:       "across a daylight saving time change",
+			at:         "2024-03-09T23:00:00-05:00",
+			active:     true,
+			currentEnd: "2024-03-10T01:00:00-05:00",
+			nextStart:  "2024-03-11T16:00:00-04:00",
+			nextEnd:    "2024-03-11T18:00:00-04:00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at, err := time.Parse(time.RFC3339, tt.at)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			status, err := evaluateRecreation(recreation, at)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if status.active != tt.active {
+				t.Errorf("active = %t, want %t", status.active, tt.active)
+			}
+			if got := formatRecreationTime(status.currentEnd); got != tt.currentEnd {
+				t.Errorf("current end = %q, want %q", got, tt.currentEnd)
+			}
+			if got := formatRecreationTime(status.next.start); got != tt.nextStart {
+				t.Errorf("next start = %q, want %q", got, tt.nextStart)
+			}
+			if got := formatRecreationTime(status.next.end); got != tt.nextEnd {
+				t.Errorf("next end = %q, want %q", got, tt.nextEnd)
+			}
+		})
+	}
+}
+
+func TestEvaluateRecreation_noWindow(t *testing.T) {
+	status, err := evaluateRecreation(&nextdns.ParentalControlRecreation{}, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status.active || !status.currentEnd.IsZero() || !status.next.start.IsZero() {
+		t.Errorf("unexpected status %+v", status)
+	}
+}
+
+func TestEvaluateRecreation_invalidTimezone(t *testing.T) {
+	recreation := &nextdns.ParentalControlRecreation{
+		Timezone: "Nowhere/Invalid",
+		Times:    &nextdns.ParentalControlRecreationTimes{},
+	}
+
+	if _, err := evaluateRecreation(recreation, time.Now()); err == nil {
+		t.Error("expected an error for an invalid time zone")
+	}
+}
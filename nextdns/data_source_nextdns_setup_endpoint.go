@@ -55,7 +55,7 @@ func dataSourceNextDNSSetupEndpoint() *schema.Resource {
 }
 
 func dataSourceNextDNSSetupEndpointRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
 	request := &nextdns.GetSetupRequest{
@@ -65,7 +65,10 @@ func dataSourceNextDNSSetupEndpointRead(ctx context.Context, d *schema.ResourceD
 
 	setup, err := client.Setup.Get(ctx, request)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error getting setup endpoint settings: %w", err))
+		if isNotFound(err) {
+			return diag.Errorf("profile %q not found", profileID)
+		}
+		return apiErrorDiags(err, "error getting setup endpoint settings")
 	}
 	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", setup))
 
@@ -47,7 +47,7 @@ func dataSourceNextDNSSetupLinkedIP() *schema.Resource {
 }
 
 func dataSourceNextDNSSetupLinkedIPRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
 	request := &nextdns.GetSetupLinkedIPRequest{
@@ -57,7 +57,10 @@ func dataSourceNextDNSSetupLinkedIPRead(ctx context.Context, d *schema.ResourceD
 
 	setup, err := client.SetupLinkedIP.Get(ctx, request)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error getting setup linkedip settings: %w", err))
+		if isNotFound(err) {
+			return diag.Errorf("profile %q not found", profileID)
+		}
+		return apiErrorDiags(err, "error getting setup linkedip settings")
 	}
 	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", setup))
 
@@ -0,0 +1,35 @@
+package nextdns
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNextDNSSetup_dataSources(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProfileConfig() + `
+data "nextdns_setup_endpoint" "test" {
+  profile_id = nextdns_profile.test.id
+}
+
+data "nextdns_setup_linkedip" "test" {
+  profile_id = nextdns_profile.test.id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.nextdns_setup_endpoint.test", "doh"),
+					resource.TestCheckResourceAttr("data.nextdns_setup_endpoint.test", "ipv4.#", "2"),
+					resource.TestCheckResourceAttr("data.nextdns_setup_linkedip.test", "ip", "203.0.113.1"),
+					resource.TestCheckResourceAttr("data.nextdns_setup_linkedip.test", "servers.#", "2"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,141 @@
+package nextdns
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/net/idna"
+)
+
+const (
+	// domainWildcard is the prefix of a domain matching its subdomains only.
+	domainWildcard = "*."
+	// maxDomainLength is the maximum length of a domain name, in its ASCII form.
+	maxDomainLength = 253
+	// maxLabelLength is the maximum length of a label of a domain name.
+	maxLabelLength = 63
+)
+
+// domainProfile converts the internationalized domain names to their ASCII form (punycode).
+// The label syntax is checked separately, as some domains found in lists contain underscores.
+var domainProfile = idna.New(
+	idna.MapForLookup(),
+	idna.BidiRule(),
+	idna.StrictDomainName(false),
+)
+
+// normalizeDomain returns the canonical form of a domain as stored by NextDNS:
+// lower case, without trailing dot and with the internationalized labels in punycode.
+// The domain is returned lower cased if it cannot be converted, the validation reporting why.
+func normalizeDomain(domain string) string {
+	name := strings.TrimSuffix(domain, ".")
+
+	prefix := ""
+	if strings.HasPrefix(name, domainWildcard) {
+		prefix, name = domainWildcard, strings.TrimPrefix(name, domainWildcard)
+	}
+
+	ascii, err := domainProfile.ToASCII(name)
+	if err != nil {
+		return strings.ToLower(domain)
+	}
+
+	return prefix + ascii
+}
+
+// validateDomainName checks that the domain is a valid hostname, optionally prefixed by a wildcard (e.g. *.example.com).
+func validateDomainName(domain string) error {
+	if strings.Contains(domain, "://") {
+		// nolint:goerr113
+		return errors.New("must be a domain, not a URL")
+	}
+	if strings.ContainsAny(domain, "/:?# ") {
+		// nolint:goerr113
+		return errors.New("must only contain the domain, without path, port or spaces")
+	}
+
+	name := strings.TrimPrefix(normalizeDomain(domain), domainWildcard)
+	if len(name) == 0 {
+		// nolint:goerr113
+		return errors.New("must not be empty")
+	}
+	if len(name) > maxDomainLength {
+		// nolint:goerr113
+		return fmt.Errorf("must be at most %d characters long", maxDomainLength)
+	}
+
+	for _, label := range strings.Split(name, ".") {
+		if err := validateDomainLabel(label); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateDomainLabel checks that the label of a domain is valid, in its ASCII form.
+func validateDomainLabel(label string) error {
+	switch {
+	case len(label) == 0:
+		// nolint:goerr113
+		return errors.New("must not contain empty labels")
+	case len(label) > maxLabelLength:
+		// nolint:goerr113
+		return fmt.Errorf("must not contain labels longer than %d characters", maxLabelLength)
+	case strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-"):
+		// nolint:goerr113
+		return fmt.Errorf("label %q must not start or end with a hyphen", label)
+	case strings.Contains(label, "*"):
+		// nolint:goerr113
+		return errors.New("must only contain a wildcard as the first label (e.g. *.example.com)")
+	}
+
+	for _, c := range label {
+		if !(c >= 'a' && c <= 'z') && !(c >= '0' && c <= '9') && c != '-' && c != '_' {
+			// nolint:goerr113
+			return fmt.Errorf("label %q contains the invalid character %q", label, c)
+		}
+	}
+
+	return nil
+}
+
+// validateDomain validates that the value is a valid domain.
+func validateDomain(v interface{}, k string) ([]string, []error) {
+	if err := validateDomainName(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%q must be a valid domain (e.g. example.com): %w", k, err)}
+	}
+
+	return nil, nil
+}
+
+// normalizeDomainState returns the canonical form of the domain to store in the state.
+func normalizeDomainState(v interface{}) string {
+	return normalizeDomain(v.(string))
+}
+
+// suppressEquivalentDomain suppresses the differences between two forms of the same domain (e.g. bücher.de and xn--bcher-kva.de),
+// including the states written before the domains were normalized.
+func suppressEquivalentDomain(_, old, new string, _ *schema.ResourceData) bool {
+	return normalizeDomain(old) == normalizeDomain(new)
+}
+
+// hashDomainEntry returns the hash of an entry of a domain list, based on its normalized domain,
+// so the different forms of the same domain are the same element of the set.
+func hashDomainEntry(v interface{}) int {
+	m := v.(map[string]interface{})
+
+	var buf bytes.Buffer
+	buf.WriteString(normalizeDomain(m["id"].(string)))
+	if active, ok := m["active"].(bool); ok {
+		buf.WriteString(fmt.Sprintf("-%t", active))
+	}
+	if expiresAt, ok := m["expires_at"].(string); ok && len(expiresAt) > 0 {
+		buf.WriteString("-" + expiresAt)
+	}
+
+	return schema.HashString(buf.String())
+}
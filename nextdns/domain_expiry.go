@@ -0,0 +1,222 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// maxReportedExpiredDomains is the number of expired domains named by the warning of the lists.
+const maxReportedExpiredDomains = 20
+
+// expiresAtSchema returns the schema of the time after which a domain is removed from its list.
+func expiresAtSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "The time after which the domain is removed from the list, in RFC 3339 format (e.g. 2024-01-02T15:04:05Z). " +
+			"The expiry is evaluated at the time of the last refresh (see expiry_evaluated_at), so the removal is planned " +
+			"by the first plan refreshing the list after that time, which warns about the expired domains until their block is removed.",
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: validateExpiresAt,
+	}
+}
+
+// expiredDomainsSchema returns the schema of the declared domains that expired.
+func expiredDomainsSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "The declared domains whose expires_at is passed, which are removed from the list.",
+		Type:        schema.TypeSet,
+		Computed:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// expiryEvaluatedAtSchema returns the schema of the time the expiry times are evaluated at.
+func expiryEvaluatedAtSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "The time of the last refresh, in RFC 3339 format, which the expiry times are evaluated at when planning. " +
+			"Pinning it in the state keeps the plan and the apply consistent when a domain expires in between.",
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+}
+
+// validateExpiresAt validates that the value is a time in RFC 3339 format.
+// The expired domains are reported once for the whole list when refreshing it, see expiredDomainsWarning.
+func validateExpiresAt(v interface{}, path cty.Path) diag.Diagnostics {
+	if _, err := time.Parse(time.RFC3339, v.(string)); err != nil {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Invalid expiry time",
+				Detail:        fmt.Sprintf("The expiry time must be in RFC 3339 format (e.g. 2024-01-02T15:04:05Z): %s", err),
+				AttributePath: path,
+			},
+		}
+	}
+
+	return nil
+}
+
+// customizeDiffExpiredDomains plans the removal of the declared domains whose expiry time is passed.
+// The expiry times are evaluated at the time of the last refresh kept in the state rather than the current time,
+// as the plan is computed again when applying and both must agree. Without a refresh (e.g. on creation),
+// the expired domains are only known when applying.
+func customizeDiffExpiredDomains(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("domain") {
+		return d.SetNewComputed("expired_domains")
+	}
+
+	evaluatedAt, err := time.Parse(time.RFC3339, d.Get("expiry_evaluated_at").(string))
+	if err != nil && hasExpiry(d.Get("domain")) {
+		return d.SetNewComputed("expired_domains")
+	}
+
+	expired := expiredDomains(d.Get("domain"), evaluatedAt)
+	if reflect.DeepEqual(toSet(expired), toSet(stringsFromSet(d.Get("expired_domains")))) {
+		return nil
+	}
+
+	return d.SetNew("expired_domains", expired)
+}
+
+// applyExpiredDomains sets the expired domains which were planned as only known when applying, as the expiry times
+// were never evaluated when planning (see customizeDiffExpiredDomains).
+func applyExpiredDomains(d *schema.ResourceData) error {
+	if len(d.Get("expiry_evaluated_at").(string)) > 0 {
+		return nil
+	}
+
+	return d.Set("expired_domains", expiredDomains(d.Get("domain"), time.Now()))
+}
+
+// refreshExpiry pins the time the expiry times are evaluated at in the state, and warns about the expired domains.
+func refreshExpiry(d *schema.ResourceData) diag.Diagnostics {
+	now := time.Now()
+	if err := d.Set("expiry_evaluated_at", now.UTC().Format(time.RFC3339)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return expiredDomainsWarning(expiredDomains(d.Get("domain"), now))
+}
+
+// expiredDomainsWarning returns a single warning naming the expired domains, limited to the first ones.
+func expiredDomainsWarning(expired []string) diag.Diagnostics {
+	if len(expired) == 0 {
+		return nil
+	}
+
+	names := expired
+	if len(names) > maxReportedExpiredDomains {
+		names = names[:maxReportedExpiredDomains]
+	}
+	detail := fmt.Sprintf("The domains %s expired, so they are removed from the list. Their blocks can be removed from the configuration.",
+		strings.Join(names, ", "))
+	if len(expired) > maxReportedExpiredDomains {
+		detail += fmt.Sprintf(" %d more domains expired, see the expired_domains attribute.", len(expired)-maxReportedExpiredDomains)
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Expired domains",
+			Detail:   detail,
+		},
+	}
+}
+
+// hasExpiry reports whether a domain of a domain set has an expiry time.
+func hasExpiry(v interface{}) bool {
+	set, ok := v.(*schema.Set)
+	if !ok {
+		return false
+	}
+
+	for _, r := range set.List() {
+		if expiresAt, _ := r.(map[string]interface{})["expires_at"].(string); len(expiresAt) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// expiredDomains returns the normalized domains of a domain set whose expiry time is before the given time.
+func expiredDomains(v interface{}, now time.Time) []string {
+	expired := make([]string, 0)
+
+	set, ok := v.(*schema.Set)
+	if !ok {
+		return expired
+	}
+
+	for _, r := range set.List() {
+		domain := r.(map[string]interface{})
+
+		expiresAt, err := time.Parse(time.RFC3339, fmt.Sprint(domain["expires_at"]))
+		if err == nil && !expiresAt.After(now) {
+			expired = append(expired, normalizeDomain(domain["id"].(string)))
+		}
+	}
+	sort.Strings(expired)
+
+	return expired
+}
+
+// withExpiry returns the domain blocks read from the API with the expiry times of the declared domains, which are
+// only known by Terraform. The expired domains that were removed from the list are kept, so they match the configuration.
+func withExpiry(domains []map[string]interface{}, declared interface{}, expired interface{}) []map[string]interface{} {
+	set, ok := declared.(*schema.Set)
+	if !ok {
+		return domains
+	}
+
+	blocks := make(map[string]map[string]interface{}, set.Len())
+	for _, r := range set.List() {
+		domain := r.(map[string]interface{})
+		blocks[normalizeDomain(domain["id"].(string))] = domain
+	}
+
+	read := make(map[string]bool, len(domains))
+	for _, domain := range domains {
+		id := domain["id"].(string)
+		read[id] = true
+
+		if block, ok := blocks[id]; ok {
+			domain["expires_at"] = block["expires_at"]
+		}
+	}
+
+	for _, id := range stringsFromSet(expired) {
+		if block, ok := blocks[id]; ok && !read[id] {
+			domains = append(domains, block)
+		}
+	}
+
+	return domains
+}
+
+// stringsFromSet returns the strings of a set of strings from the resource data.
+func stringsFromSet(v interface{}) []string {
+	set, ok := v.(*schema.Set)
+	if !ok {
+		return []string{}
+	}
+
+	values := make([]string, 0, set.Len())
+	for _, e := range set.List() {
+		values = append(values, e.(string))
+	}
+	sort.Strings(values)
+
+	return values
+}
@@ -0,0 +1,148 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestExpiredDomains(t *testing.T) {
+	now := time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+
+	set := schema.NewSet(hashDomainEntry, []interface{}{
+		map[string]interface{}{"id": "permanent.com", "active": true, "expires_at": ""},
+		map[string]interface{}{"id": "Expired.com", "active": true, "expires_at": "2024-01-02T14:59:59Z"},
+		map[string]interface{}{"id": "now.com", "active": true, "expires_at": "2024-01-02T16:00:00+01:00"},
+		map[string]interface{}{"id": "later.com", "active": true, "expires_at": "2024-01-02T15:00:01Z"},
+	})
+
+	want := []string{"expired.com", "now.com"}
+	if got := expiredDomains(set, now); !reflect.DeepEqual(got, want) {
+		t.Errorf("expiredDomains() = %v, want %v", got, want)
+	}
+}
+
+func TestWithExpiry(t *testing.T) {
+	declared := schema.NewSet(hashDomainEntry, []interface{}{
+		map[string]interface{}{"id": "example.com", "active": true, "expires_at": "2030-01-01T00:00:00Z"},
+		map[string]interface{}{"id": "expired.com", "active": true, "expires_at": "2020-01-01T00:00:00Z"},
+	})
+	expired := schema.NewSet(schema.HashString, []interface{}{"expired.com"})
+
+	domains := withExpiry([]map[string]interface{}{
+		{"id": "example.com", "active": true},
+		{"id": "other.com", "active": false},
+	}, declared, expired)
+
+	want := []map[string]interface{}{
+		{"id": "example.com", "active": true, "expires_at": "2030-01-01T00:00:00Z"},
+		{"id": "other.com", "active": false},
+		{"id": "expired.com", "active": true, "expires_at": "2020-01-01T00:00:00Z"},
+	}
+	if !reflect.DeepEqual(domains, want) {
+		t.Errorf("withExpiry() = %v, want %v", domains, want)
+	}
+}
+
+func TestCustomizeDiffExpiredDomains(t *testing.T) {
+	domain := map[string]interface{}{"id": "example.org", "active": true, "expires_at": "2021-01-01T00:00:00Z"}
+	hash := strconv.Itoa(hashDomainEntry(domain))
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"profile_id": "abc123",
+		"domain":     []interface{}{domain},
+	})
+
+	tests := []struct {
+		name        string
+		evaluatedAt string
+		computed    bool
+		expired     bool
+	}{
+		// The domain expired before the current time, but after the last refresh: the plan and the apply agree
+		// on keeping it until the next refresh, whenever they run.
+		{name: "expiry after the last refresh", evaluatedAt: "2020-06-01T00:00:00Z"},
+		{name: "expiry before the last refresh", evaluatedAt: "2021-06-01T00:00:00Z", expired: true},
+		{name: "never refreshed", computed: true},
+	}
+
+	r := resourceNextDNSDenylist()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var state *terraform.InstanceState
+			if len(tt.evaluatedAt) > 0 {
+				state = &terraform.InstanceState{
+					ID: "abc123",
+					Attributes: map[string]string{
+						"id":                             "abc123",
+						"profile_id":                     "abc123",
+						"authoritative":                  "true",
+						"domain.#":                       "1",
+						"domain." + hash + ".id":         "example.org",
+						"domain." + hash + ".active":     "true",
+						"domain." + hash + ".expires_at": "2021-01-01T00:00:00Z",
+						"expired_domains.#":              "0",
+						"expiry_evaluated_at":            tt.evaluatedAt,
+					},
+				}
+			}
+
+			// The plan is computed again when applying, which must give the same result.
+			for i := 0; i < 2; i++ {
+				diff, err := r.Diff(context.Background(), state, config, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var computed, expired bool
+				if diff != nil {
+					if attr := diff.Attributes["expired_domains.#"]; attr != nil {
+						computed = attr.NewComputed
+						expired = attr.New == "1"
+					}
+				}
+				if computed != tt.computed || expired != tt.expired {
+					t.Errorf("expired_domains computed = %t, expired = %t, want %t and %t", computed, expired, tt.computed, tt.expired)
+				}
+			}
+		})
+	}
+}
+
+func TestExpiredDomainsWarning(t *testing.T) {
+	if diags := expiredDomainsWarning(nil); diags != nil {
+		t.Errorf("unexpected diagnostics %v", diags)
+	}
+
+	expired := make([]string, 0, maxReportedExpiredDomains+5)
+	for i := 0; i < maxReportedExpiredDomains+5; i++ {
+		expired = append(expired, fmt.Sprintf("domain%02d.com", i))
+	}
+
+	diags := expiredDomainsWarning(expired)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+	if !strings.Contains(diags[0].Detail, "domain19.com") || strings.Contains(diags[0].Detail, "domain20.com") ||
+		!strings.Contains(diags[0].Detail, "5 more domains") {
+		t.Errorf("unexpected detail %q", diags[0].Detail)
+	}
+}
+
+func TestValidateExpiresAt(t *testing.T) {
+	if diags := validateExpiresAt("2020-01-01T00:00:00Z", cty.Path{}); len(diags) > 0 {
+		t.Errorf("unexpected diagnostics for a past time %v", diags)
+	}
+	if diags := validateExpiresAt("tomorrow", cty.Path{}); !diags.HasError() {
+		t.Error("expected an error")
+	}
+}
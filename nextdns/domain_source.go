@@ -0,0 +1,354 @@
+package nextdns
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// domainSourceHosts is the format of a hosts file (e.g. 0.0.0.0 example.com).
+	domainSourceHosts = "hosts"
+	// domainSourceAdblock is the format of an Adblock Plus list (e.g. ||example.com^).
+	domainSourceAdblock = "adblock"
+	// domainSourcePlain is the format of a list with a domain per line.
+	domainSourcePlain = "plain"
+	// domainSourceCSV is the format of a CSV file with the domain in the first column.
+	domainSourceCSV = "csv"
+)
+
+// hostsLocalNames are the names of the local host found in hosts files, which are not domains to list.
+var hostsLocalNames = map[string]bool{
+	"localhost":             true,
+	"localhost.localdomain": true,
+	"local":                 true,
+	"broadcasthost":         true,
+	"ip6-localhost":         true,
+	"ip6-loopback":          true,
+	"ip6-localnet":          true,
+	"ip6-mcastprefix":       true,
+	"ip6-allnodes":          true,
+	"ip6-allrouters":        true,
+	"ip6-allhosts":          true,
+	"0.0.0.0":               true,
+}
+
+// domainSourceSchema returns the schema of the lists of domains loaded into a deny or allow list.
+func domainSourceSchema(exceptions string) *schema.Schema {
+	return &schema.Schema{
+		Description: "A list of domains to load, merged with the domain blocks, which take precedence. " +
+			"The list is read when planning, so the changes of a local file are planned as any other change. " +
+			exceptions,
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"content": {
+					Description: "The content of the list. Exactly one of content or path must be set.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"path": {
+					Description: "The path of a local file holding the list. Exactly one of content or path must be set.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"format": {
+					Description: "The format of the list: hosts (e.g. 0.0.0.0 example.com), adblock (e.g. ||example.com^), " +
+						"plain (a domain per line) or csv (the domain in the first column, after an optional header). " +
+						"Comments are ignored, as well as the adblock rules that do not apply to a whole domain.",
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{domainSourceHosts, domainSourceAdblock, domainSourcePlain, domainSourceCSV}, false),
+				},
+				"active": {
+					Description: "Whether the domains of the list are active.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+				},
+			},
+		},
+	}
+}
+
+// sourceDomainSchema returns the schema of the domains loaded from the sources.
+func sourceDomainSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "The domains loaded from the sources, except the domains declared by the domain blocks.",
+		Type:        schema.TypeSet,
+		Computed:    true,
+		Set:         hashDomainEntry,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"active": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// customizeDiffDomainSources returns the function loading the sources of a deny or allow list when planning,
+// so the domains they hold are planned in the source_domain attribute.
+// The exceptions of the adblock lists are added to an allow list, and removed from a deny list.
+func customizeDiffDomainSources(allowExceptions bool) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		sources, _ := d.Get("source").([]interface{})
+		for i := range sources {
+			if !d.NewValueKnown(fmt.Sprintf("source.%d.content", i)) || !d.NewValueKnown(fmt.Sprintf("source.%d.path", i)) {
+				return d.SetNewComputed("source_domain")
+			}
+		}
+		if !d.NewValueKnown("domain") {
+			return d.SetNewComputed("source_domain")
+		}
+
+		domains, err := loadDomainSources(sources, allowExceptions)
+		if err != nil {
+			return err
+		}
+		for id := range domainsFromSet(d.Get("domain")) {
+			delete(domains, id)
+		}
+
+		if reflect.DeepEqual(domains, domainsFromSet(d.Get("source_domain"))) {
+			return nil
+		}
+
+		return d.SetNew("source_domain", flattenDomains(domains))
+	}
+}
+
+// loadDomainSources returns the domains of the sources, indexed by the normalized domain and holding if it is active.
+func loadDomainSources(sources []interface{}, allowExceptions bool) (map[string]bool, error) {
+	domains := make(map[string]bool)
+	excluded := make(map[string]bool)
+
+	for i, s := range sources {
+		source, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		content, err := readDomainSource(source)
+		if err != nil {
+			return nil, fmt.Errorf("error loading source %d: %w", i, err)
+		}
+
+		listed, exceptions, err := parseDomainSource(content, source["format"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing source %d: %w", i, err)
+		}
+
+		active := source["active"].(bool)
+		for _, id := range listed {
+			domains[id] = active
+		}
+		for _, id := range exceptions {
+			if allowExceptions {
+				domains[id] = active
+			} else {
+				excluded[id] = true
+			}
+		}
+	}
+
+	for id := range excluded {
+		delete(domains, id)
+	}
+
+	return domains, nil
+}
+
+// readDomainSource returns the content of a source, either inline or read from its file.
+func readDomainSource(source map[string]interface{}) (string, error) {
+	content, _ := source["content"].(string)
+	path, _ := source["path"].(string)
+
+	switch {
+	case len(content) > 0 && len(path) > 0:
+		// nolint:goerr113
+		return "", errors.New("only one of content or path must be set")
+	case len(path) > 0:
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("error reading %q: %w", path, err)
+		}
+		return string(b), nil
+	case len(content) > 0:
+		return content, nil
+	default:
+		// nolint:goerr113
+		return "", errors.New("one of content or path must be set")
+	}
+}
+
+// parseDomainSource returns the normalized domains listed by the content of a source in the given format,
+// and the domains excepted by its exception rules (e.g. @@||example.com^).
+func parseDomainSource(content string, format string) ([]string, []string, error) {
+	if format == domainSourceCSV {
+		return parseCSVSource(content)
+	}
+
+	var domains, exceptions []string
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var names []string
+		exception := false
+
+		switch format {
+		case domainSourceHosts:
+			names = parseHostsLine(scanner.Text())
+		case domainSourceAdblock:
+			var name string
+			name, exception = parseAdblockLine(scanner.Text())
+			if len(name) > 0 {
+				names = []string{name}
+			}
+		default:
+			text, _, _ := strings.Cut(scanner.Text(), "#")
+			if text = strings.TrimSpace(text); len(text) > 0 {
+				names = []string{text}
+			}
+		}
+
+		for _, name := range names {
+			if err := validateDomainName(name); err != nil {
+				return nil, nil, fmt.Errorf("line %d: %q must be a valid domain: %w", line, name, err)
+			}
+			if exception {
+				exceptions = append(exceptions, normalizeDomain(name))
+			} else {
+				domains = append(domains, normalizeDomain(name))
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return domains, exceptions, nil
+}
+
+// parseHostsLine returns the names of a line of a hosts file (e.g. 0.0.0.0 example.com www.example.com),
+// ignoring the names of the local host.
+func parseHostsLine(line string) []string {
+	text, _, _ := strings.Cut(line, "#")
+	fields := strings.Fields(text)
+	if len(fields) < 2 || net.ParseIP(fields[0]) == nil {
+		return nil
+	}
+
+	var names []string
+	for _, name := range fields[1:] {
+		if !hostsLocalNames[strings.ToLower(name)] {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// parseAdblockLine returns the domain of a rule of an adblock list (e.g. ||example.com^), and whether it is an exception rule.
+// The comments and the rules that do not apply to a whole domain (e.g. cosmetic rules or paths) are ignored.
+func parseAdblockLine(line string) (string, bool) {
+	rule := strings.TrimSpace(line)
+	if strings.HasPrefix(rule, "!") || strings.HasPrefix(rule, "[") || strings.Contains(rule, "#") {
+		return "", false
+	}
+
+	exception := strings.HasPrefix(rule, "@@")
+	rule = strings.TrimPrefix(rule, "@@")
+	if !strings.HasPrefix(rule, "||") {
+		return "", false
+	}
+
+	// The options (e.g. $important) do not change the domain the rule applies to.
+	rule, _, _ = strings.Cut(strings.TrimPrefix(rule, "||"), "$")
+	name, rest, _ := strings.Cut(rule, "^")
+	if len(name) == 0 || len(rest) > 0 || strings.ContainsAny(name, "/*|") {
+		return "", false
+	}
+
+	return name, exception
+}
+
+// parseCSVSource returns the normalized domains of the first column of a CSV file, skipping its header if any.
+func parseCSVSource(content string) ([]string, []string, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var domains []string
+	for record := 1; ; record++ {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		name := strings.TrimSpace(fields[0])
+		if len(name) == 0 {
+			continue
+		}
+		// The header is recognized as its first column is not a domain name (e.g. domain).
+		if record == 1 && !strings.Contains(name, ".") {
+			continue
+		}
+
+		if err := validateDomainName(name); err != nil {
+			return nil, nil, fmt.Errorf("record %d: %q must be a valid domain: %w", record, name, err)
+		}
+		domains = append(domains, normalizeDomain(name))
+	}
+
+	return domains, nil, nil
+}
+
+// flattenDomains returns the domain blocks of the domains, indexed by domain and holding if it is active.
+func flattenDomains(domains map[string]bool) []map[string]interface{} {
+	blocks := make([]map[string]interface{}, 0, len(domains))
+	for _, id := range sortedKeys(domains) {
+		blocks = append(blocks, map[string]interface{}{
+			"id":     id,
+			"active": domains[id],
+		})
+	}
+
+	return blocks
+}
+
+// declaredDomains returns the domains declared by the domain blocks and loaded from the sources, except the expired ones,
+// indexed by the normalized domain and holding if it is active.
+func declaredDomains(domain interface{}, sourceDomain interface{}, expired interface{}) map[string]bool {
+	domains := domainsFromSet(sourceDomain)
+	for id, active := range domainsFromSet(domain) {
+		domains[id] = active
+	}
+	for _, id := range stringsFromSet(expired) {
+		delete(domains, id)
+	}
+
+	return domains
+}
@@ -0,0 +1,97 @@
+package nextdns
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDomainSource(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     string
+		content    string
+		domains    []string
+		exceptions []string
+		invalid    bool
+	}{
+		{
+			name:    "hosts",
+			format:  domainSourceHosts,
+			content: "# comment\n127.0.0.1 localhost\n::1 ip6-localhost\n0.0.0.0 Ads.example.com www.ads.example.com # inline\n\n",
+			domains: []string{"ads.example.com", "www.ads.example.com"},
+		},
+		{
+			name:       "adblock",
+			format:     domainSourceAdblock,
+			content:    "[Adblock Plus 2.0]\n! comment\n||example.com^\n||tracker.com^$important\n@@||cdn.example.com^\nexample.org##.ad\n||example.net/path^\n/banner/*\n",
+			domains:    []string{"example.com", "tracker.com"},
+			exceptions: []string{"cdn.example.com"},
+		},
+		{
+			name:    "plain",
+			format:  domainSourcePlain,
+			content: "example.com\n# comment\n*.example.org # inline\nBücher.de\n",
+			domains: []string{"example.com", "*.example.org", "xn--bcher-kva.de"},
+		},
+		{
+			name:    "csv",
+			format:  domainSourceCSV,
+			content: "domain,category\nexample.com,ads\n# comment\n\"example.org\",tracking\n",
+			domains: []string{"example.com", "example.org"},
+		},
+		{
+			name:    "invalid domain",
+			format:  domainSourcePlain,
+			content: "example.com\nhttps://example.org/\n",
+			invalid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			domains, exceptions, err := parseDomainSource(tt.content, tt.format)
+			if tt.invalid {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(domains, tt.domains) {
+				t.Errorf("domains = %v, want %v", domains, tt.domains)
+			}
+			if !reflect.DeepEqual(exceptions, tt.exceptions) {
+				t.Errorf("exceptions = %v, want %v", exceptions, tt.exceptions)
+			}
+		})
+	}
+}
+
+func TestLoadDomainSources(t *testing.T) {
+	sources := []interface{}{
+		map[string]interface{}{"format": domainSourcePlain, "content": "example.com\nexample.org\n", "active": false},
+		map[string]interface{}{"format": domainSourceAdblock, "content": "||example.com^\n@@||example.org^\n", "active": true},
+	}
+
+	denied, err := loadDomainSources(sources, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]bool{"example.com": true}; !reflect.DeepEqual(denied, want) {
+		t.Errorf("deny list domains = %v, want %v", denied, want)
+	}
+
+	allowed, err := loadDomainSources(sources, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]bool{"example.com": true, "example.org": true}; !reflect.DeepEqual(allowed, want) {
+		t.Errorf("allow list domains = %v, want %v", allowed, want)
+	}
+
+	if _, err := loadDomainSources([]interface{}{map[string]interface{}{"format": domainSourcePlain, "active": true}}, false); err == nil {
+		t.Error("expected an error for a source without content nor path")
+	}
+}
@@ -0,0 +1,55 @@
+package nextdns
+
+import (
+	"testing"
+)
+
+func TestNormalizeDomain(t *testing.T) {
+	tests := []struct {
+		domain string
+		want   string
+	}{
+		{domain: "example.com", want: "example.com"},
+		{domain: "Example.COM.", want: "example.com"},
+		{domain: "Bücher.de", want: "xn--bcher-kva.de"},
+		{domain: "xn--bcher-kva.de", want: "xn--bcher-kva.de"},
+		{domain: "*.Example.com", want: "*.example.com"},
+		{domain: "_dmarc.example.com", want: "_dmarc.example.com"},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeDomain(tt.domain); got != tt.want {
+			t.Errorf("normalizeDomain(%q) = %q, want %q", tt.domain, got, tt.want)
+		}
+	}
+}
+
+func TestValidateDomainName(t *testing.T) {
+	tests := []struct {
+		domain string
+		valid  bool
+	}{
+		{domain: "example.com", valid: true},
+		{domain: "Bücher.de", valid: true},
+		{domain: "*.example.com", valid: true},
+		{domain: "sub.example.com.", valid: true},
+		{domain: "", valid: false},
+		{domain: "https://example.com", valid: false},
+		{domain: "example.com/path", valid: false},
+		{domain: "example.com:443", valid: false},
+		{domain: "a.*.com", valid: false},
+		{domain: "-bad.com", valid: false},
+		{domain: "example..com", valid: false},
+		{domain: "exa$mple.com", valid: false},
+	}
+
+	for _, tt := range tests {
+		err := validateDomainName(tt.domain)
+		if tt.valid && err != nil {
+			t.Errorf("validateDomainName(%q) returned an error: %s", tt.domain, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("validateDomainName(%q) did not return an error", tt.domain)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// authoritativeSchema returns the schema of the flag that controls if a resource owns the whole list.
+func authoritativeSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Whether the resource owns the whole list, removing the entries that are not declared in the configuration. " +
+			"When false, only the declared entries are added or removed, and the entries added outside of Terraform are left untouched.",
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  true,
+	}
+}
+
+// listAuthoritativeSchema returns the schema of the authoritative flag of a domain list (e.g. denylist), which conflicts
+// with the resource managing single domains of the list (e.g. nextdns_denylist_domain).
+func listAuthoritativeSchema(domainResource string) *schema.Schema {
+	s := authoritativeSchema()
+	s.Description += fmt.Sprintf(" When true, the domains of %[1]s resources on the same profile are removed too, "+
+		"so both can only be combined when false. An imported list is not authoritative, and the plan following "+
+		"the import shows the switch to true unless the configuration sets false.", domainResource)
+
+	return s
+}
+
+// syncDomainList adds, updates and removes only the declared domains of a list of a profile (e.g. denylist),
+// leaving the domains added outside of Terraform untouched.
+// The maps are indexed by the domain and hold if the domain is active.
+func syncDomainList(ctx context.Context, api *apiClient, profileID string, list string, existing, previous, desired map[string]bool) error {
+	for _, id := range sortedKeys(desired) {
+		active := desired[id]
+
+		current, ok := existing[id]
+		switch {
+		case !ok:
+			tflog.Debug(ctx, fmt.Sprintf("adding %q to %s", id, list))
+
+			entry := map[string]interface{}{"id": id, "active": active}
+			if err := api.addListEntry(ctx, profileID, list, entry); err != nil {
+				return &entryError{action: "adding", id: id, err: err}
+			}
+		case current != active:
+			tflog.Debug(ctx, fmt.Sprintf("updating %q of %s", id, list))
+
+			entry := map[string]interface{}{"active": active}
+			if err := api.do(ctx, http.MethodPatch, profilePath(profileID, list, id), entry, nil); err != nil {
+				return &entryError{action: "updating", id: id, err: err}
+			}
+		}
+	}
+
+	for _, id := range sortedKeys(previous) {
+		if _, ok := desired[id]; ok {
+			continue
+		}
+		if _, ok := existing[id]; !ok {
+			continue
+		}
+		tflog.Debug(ctx, fmt.Sprintf("removing %q from %s", id, list))
+
+		if err := api.deleteListEntry(ctx, profileID, list, id); err != nil {
+			return &entryError{action: "removing", id: id, err: err}
+		}
+	}
+
+	return nil
+}
+
+// syncIDList adds and removes only the declared IDs of a list of a profile (e.g. security/tlds),
+// leaving the IDs added outside of Terraform untouched.
+func syncIDList(ctx context.Context, api *apiClient, profileID string, list string, existing, previous, desired []string) error {
+	existingIDs := toSet(existing)
+	desiredIDs := toSet(desired)
+
+	for _, id := range desired {
+		if existingIDs[id] {
+			continue
+		}
+		tflog.Debug(ctx, fmt.Sprintf("adding %q to %s", id, list))
+
+		if err := api.addListEntry(ctx, profileID, list, map[string]string{"id": id}); err != nil {
+			return &entryError{action: "adding", id: id, err: err}
+		}
+	}
+
+	for _, id := range previous {
+		if desiredIDs[id] || !existingIDs[id] {
+			continue
+		}
+		tflog.Debug(ctx, fmt.Sprintf("removing %q from %s", id, list))
+
+		if err := api.deleteListEntry(ctx, profileID, list, id); err != nil {
+			return &entryError{action: "removing", id: id, err: err}
+		}
+	}
+
+	return nil
+}
+
+// importDomainList imports the whole domain list of a profile (e.g. denylist). Every domain of the list is imported,
+// but the resource is not authoritative until the configuration makes it so, the plan showing the change.
+func importDomainList(ctx context.Context, d *schema.ResourceData, meta interface{}, read schema.ReadContextFunc) ([]*schema.ResourceData, error) {
+	profileID := d.Id()
+	d.SetId(profileID)
+	d.Set("profile_id", profileID)
+
+	// The list is read as if authoritative, so the domains added outside of Terraform are imported too.
+	d.Set("authoritative", true)
+	if err := importReadError(d, read(ctx, d, meta)); err != nil {
+		return nil, fmt.Errorf("error importing the list of profile %q: %w", profileID, err)
+	}
+	d.Set("authoritative", false)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// importListDomain imports a domain of a list of a profile (e.g. denylist) from an ID in the <profile_id>/<domain>
+// format, failing when the domain is not in the list or the list cannot be read.
+func importListDomain(ctx context.Context, d *schema.ResourceData, meta interface{}, read schema.ReadContextFunc) ([]*schema.ResourceData, error) {
+	profileID, domain, err := parseTwoPartID(d.Id(), "domain")
+	if err != nil {
+		return nil, err
+	}
+	domain = normalizeDomain(domain)
+
+	d.SetId(profileID + "/" + domain)
+	d.Set("profile_id", profileID)
+	d.Set("domain", domain)
+
+	if err := importReadError(d, read(ctx, d, meta)); err != nil {
+		return nil, fmt.Errorf("error importing domain %q of profile %q: %w", domain, profileID, err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// domainsFromSet returns the domains of a domain set, indexed by the normalized domain and holding if it is active.
+func domainsFromSet(v interface{}) map[string]bool {
+	domains := make(map[string]bool)
+
+	set, ok := v.(*schema.Set)
+	if !ok {
+		return domains
+	}
+
+	for _, r := range set.List() {
+		domain := r.(map[string]interface{})
+		domains[normalizeDomain(domain["id"].(string))] = domain["active"].(bool)
+	}
+
+	return domains
+}
+
+// stringsFromList returns the strings of a list of strings from the resource data.
+func stringsFromList(v interface{}) []string {
+	list, _ := v.([]interface{})
+
+	values := make([]string, 0, len(list))
+	for _, e := range list {
+		values = append(values, e.(string))
+	}
+
+	return values
+}
+
+// filterDeclared returns the IDs that are declared, keeping the original order.
+func filterDeclared(ids []string, declared []string) []string {
+	declaredIDs := toSet(declared)
+
+	filtered := make([]string, 0)
+	for _, id := range ids {
+		if declaredIDs[id] {
+			filtered = append(filtered, id)
+		}
+	}
+
+	return filtered
+}
+
+// toSet returns the values as a set.
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+
+	return set
+}
+
+// sortedKeys returns the keys of the map in a deterministic order.
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,42 @@
+package nextdns
+
+import (
+	"sync"
+)
+
+// mutexKV is a set of mutexes identified by a key, so callers holding different keys do not block each other.
+type mutexKV struct {
+	lock  sync.Mutex
+	store map[string]*sync.Mutex
+}
+
+// newMutexKV returns an empty set of keyed mutexes.
+func newMutexKV() *mutexKV {
+	return &mutexKV{
+		store: make(map[string]*sync.Mutex),
+	}
+}
+
+// Lock locks the mutex of the key, waiting until it is available.
+func (m *mutexKV) Lock(key string) {
+	m.get(key).Lock()
+}
+
+// Unlock unlocks the mutex of the key.
+func (m *mutexKV) Unlock(key string) {
+	m.get(key).Unlock()
+}
+
+// get returns the mutex of the key, creating it if needed.
+func (m *mutexKV) get(key string) *sync.Mutex {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	mutex, ok := m.store[key]
+	if !ok {
+		mutex = &sync.Mutex{}
+		m.store[key] = mutex
+	}
+
+	return mutex
+}
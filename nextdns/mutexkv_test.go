@@ -0,0 +1,51 @@
+package nextdns
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMutexKV(t *testing.T) {
+	m := newMutexKV()
+
+	m.Lock("profile1")
+
+	// A different key must not wait for the first one.
+	done := make(chan struct{})
+	go func() {
+		m.Lock("profile2")
+		m.Unlock("profile2")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("locking a different key blocked")
+	}
+
+	// The same key must wait until it is unlocked.
+	var mu sync.Mutex
+	unlocked := false
+	done = make(chan struct{})
+	go func() {
+		m.Lock("profile1")
+		defer m.Unlock("profile1")
+
+		mu.Lock()
+		defer mu.Unlock()
+		if !unlocked {
+			t.Error("locking the same key did not wait")
+		}
+		close(done)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	unlocked = true
+	mu.Unlock()
+	m.Unlock("profile1")
+
+	<-done
+}
@@ -0,0 +1,152 @@
+package nextdns
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// parentalControlCatalogJSON is the catalog of the services and categories of the parental control known by the provider.
+//
+//go:embed catalog/parental_control.json
+var parentalControlCatalogJSON []byte
+
+// maxCatalogSuggestionDistance is the edit distance up to which an unknown identifier is suggested a known one.
+const maxCatalogSuggestionDistance = 2
+
+// catalogEntry is a service or a category of the parental control.
+type catalogEntry struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// parentalControlCatalog is the catalog of the services and categories of the parental control.
+type parentalControlCatalog struct {
+	Services   []catalogEntry `json:"services"`
+	Categories []catalogEntry `json:"categories"`
+}
+
+// catalog is the embedded catalog of the parental control.
+var catalog = mustParseParentalControlCatalog(parentalControlCatalogJSON)
+
+// mustParseParentalControlCatalog parses the catalog, panicking when it is invalid as it is embedded in the provider.
+func mustParseParentalControlCatalog(data []byte) *parentalControlCatalog {
+	c := &parentalControlCatalog{}
+	if err := json.Unmarshal(data, c); err != nil {
+		panic(fmt.Sprintf("invalid parental control catalog: %v", err))
+	}
+
+	return c
+}
+
+// unknownCatalogIDs returns the identifiers missing from the entries of the catalog, with the known identifier
+// they are probably a typo of, if any.
+func unknownCatalogIDs(kind string, ids []string, entries []catalogEntry) []string {
+	known := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		known[e.ID] = true
+	}
+
+	var unknown []string
+	for _, id := range ids {
+		// The identifiers are not known yet when planning.
+		if len(id) == 0 || known[id] {
+			continue
+		}
+
+		if suggestion := suggestCatalogID(id, entries); len(suggestion) > 0 {
+			unknown = append(unknown, fmt.Sprintf("unknown %s %q, did you mean %q?", kind, id, suggestion))
+		} else {
+			unknown = append(unknown, fmt.Sprintf("unknown %s %q", kind, id))
+		}
+	}
+
+	return unknown
+}
+
+// suggestCatalogID returns the identifier of the entries closest to the identifier, or an empty string
+// when none is close enough.
+func suggestCatalogID(id string, entries []catalogEntry) string {
+	id = strings.ToLower(id)
+
+	suggestion := ""
+	best := maxCatalogSuggestionDistance + 1
+	for _, e := range entries {
+		// The name is matched too, as it is often used instead of the identifier (e.g. disney+).
+		for _, candidate := range []string{e.ID, strings.ToLower(e.Name)} {
+			if distance := levenshtein(id, candidate); distance < best {
+				best = distance
+				suggestion = e.ID
+			}
+		}
+	}
+
+	return suggestion
+}
+
+// levenshtein returns the edit distance between two strings, which is the number of insertions,
+// deletions and substitutions of characters to turn one into the other.
+func levenshtein(a, b string) int {
+	s, t := []rune(a), []rune(b)
+
+	previous := make([]int, len(t)+1)
+	current := make([]int, len(t)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		current[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(t)]
+}
+
+// customizeDiffParentalControlCatalog returns the function validating the services and categories of the parental
+// control against the catalog when planning, unless unknown identifiers are allowed. The prefix is the path
+// of the parental control attributes (e.g. parental_control.0.).
+func customizeDiffParentalControlCatalog(prefix string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		if allow, ok := d.Get(prefix + "allow_unknown_ids").(bool); !ok || allow || !d.NewValueKnown(prefix+"allow_unknown_ids") {
+			return nil
+		}
+
+		ids := func(key string) []string {
+			set, ok := d.Get(prefix + key).(*schema.Set)
+			if !ok {
+				return nil
+			}
+
+			var values []string
+			for _, v := range set.List() {
+				values = append(values, v.(map[string]interface{})["id"].(string))
+			}
+			sort.Strings(values)
+			return values
+		}
+
+		unknown := unknownCatalogIDs("service", ids("service"), catalog.Services)
+		unknown = append(unknown, unknownCatalogIDs("category", ids("category"), catalog.Categories)...)
+		if len(unknown) == 0 {
+			return nil
+		}
+
+		// nolint:goerr113
+		return fmt.Errorf("%s\nSet %sallow_unknown_ids to true to use services or categories missing from the catalog of the provider.",
+			strings.Join(unknown, "\n"), prefix)
+	}
+}
@@ -0,0 +1,166 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestParentalControlCatalog(t *testing.T) {
+	for kind, entries := range map[string][]catalogEntry{"service": catalog.Services, "category": catalog.Categories} {
+		if len(entries) == 0 {
+			t.Errorf("no %s in the catalog", kind)
+		}
+
+		seen := make(map[string]bool, len(entries))
+		for _, e := range entries {
+			if len(e.ID) == 0 || len(e.Name) == 0 || len(e.Description) == 0 {
+				t.Errorf("incomplete %s %+v", kind, e)
+			}
+			if seen[e.ID] {
+				t.Errorf("duplicate %s %q", kind, e.ID)
+			}
+			seen[e.ID] = true
+		}
+	}
+}
+
+func TestUnknownCatalogIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		ids     []string
+		entries []catalogEntry
+		want    []string
+	}{
+		{
+			name:    "known",
+			kind:    "service",
+			ids:     []string{"tiktok", "youtube"},
+			entries: catalog.Services,
+		},
+		{
+			name:    "typo",
+			kind:    "service",
+			ids:     []string{"tiktock", "instagran"},
+			entries: catalog.Services,
+			want:    []string{`unknown service "tiktock", did you mean "tiktok"?`, `unknown service "instagran", did you mean "instagram"?`},
+		},
+		{
+			name:    "display name",
+			kind:    "service",
+			ids:     []string{"Disney+", "Prime Video"},
+			entries: catalog.Services,
+			want:    []string{`unknown service "Disney+", did you mean "disneyplus"?`, `unknown service "Prime Video", did you mean "primevideo"?`},
+		},
+		{
+			name:    "no suggestion",
+			kind:    "category",
+			ids:     []string{"weapons"},
+			entries: catalog.Categories,
+			want:    []string{`unknown category "weapons"`},
+		},
+		{
+			name:    "kind",
+			kind:    "category",
+			ids:     []string{"social-network", "tiktok"},
+			entries: catalog.Categories,
+			want:    []string{`unknown category "social-network", did you mean "social-networks"?`, `unknown category "tiktok"`},
+		},
+		{
+			name:    "unknown when planning",
+			kind:    "service",
+			ids:     []string{""},
+			entries: catalog.Services,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unknownCatalogIDs(tt.kind, tt.ids, tt.entries); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("unknownCatalogIDs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "", want: 0},
+		{a: "tiktok", b: "tiktok", want: 0},
+		{a: "tiktock", b: "tiktok", want: 1},
+		{a: "", b: "zoom", want: 4},
+		{a: "kitten", b: "sitting", want: 3},
+		{a: "disney+", b: "disneyplus", want: 4},
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCustomizeDiffParentalControlCatalog(t *testing.T) {
+	const parentalControl = `"safe_search": true, "youtube_restricted_mode": false, "block_bypass": true,
+		"service": [{"id": "tiktock", "active": true, "recreation": false}]`
+
+	resources := []struct {
+		name     string
+		resource *schema.Resource
+		config   func(allow string) string
+	}{
+		{
+			name:     "nextdns_parental_control",
+			resource: resourceNextDNSParentalControl(),
+			config: func(allow string) string {
+				return fmt.Sprintf(`{"profile_id": "abc123", "allow_unknown_ids": %s, %s}`, allow, parentalControl)
+			},
+		},
+		{
+			name:     "nextdns_profile",
+			resource: resourceNextDNSProfile(),
+			config: func(allow string) string {
+				return fmt.Sprintf(`{"name": "test", "parental_control": [{"allow_unknown_ids": %s, %s}]}`, allow, parentalControl)
+			},
+		},
+	}
+
+	tests := []struct {
+		allow     string
+		wantError bool
+	}{
+		{allow: "null", wantError: true},
+		{allow: "false", wantError: true},
+		{allow: "true", wantError: false},
+	}
+
+	for _, r := range resources {
+		for _, tt := range tests {
+			t.Run(r.name+"/"+tt.allow, func(t *testing.T) {
+				block := schema.InternalMap(r.resource.Schema).CoreConfigSchema()
+				config, err := ctyjson.Unmarshal([]byte(r.config(tt.allow)), block.ImpliedType())
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				_, err = r.resource.Diff(context.Background(), nil, terraform.NewResourceConfigShimmed(config, block), nil)
+				if (err != nil) != tt.wantError {
+					t.Fatalf("unexpected error %v", err)
+				}
+				if err != nil && !strings.Contains(err.Error(), `unknown service "tiktock", did you mean "tiktok"?`) {
+					t.Errorf("unexpected error %v", err)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/amalucelli/nextdns-go/nextdns"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// profileCache holds the profiles fetched from the API, so the resources reading sections of a same profile
+// share a single request. A profile is dropped from the cache as soon as a request changes it.
+type profileCache struct {
+	client *nextdns.Client
+
+	lock    sync.Mutex
+	entries map[string]*cachedProfile
+}
+
+// cachedProfile is a profile of the cache, which is ready once the request fetching it is done.
+type cachedProfile struct {
+	done    chan struct{}
+	profile *nextdns.Profile
+	err     error
+}
+
+// newProfileCache returns an empty cache of profiles.
+func newProfileCache() *profileCache {
+	return &profileCache{
+		entries: make(map[string]*cachedProfile),
+	}
+}
+
+// get returns the profile from the cache, fetching it if needed.
+// The returned profile is shared with the other callers so it must not be modified.
+func (c *profileCache) get(ctx context.Context, profileID string) (*nextdns.Profile, error) {
+	c.lock.Lock()
+	entry, ok := c.entries[profileID]
+	if !ok {
+		entry = &cachedProfile{
+			done: make(chan struct{}),
+		}
+		c.entries[profileID] = entry
+	}
+	c.lock.Unlock()
+
+	if ok {
+		tflog.Debug(ctx, fmt.Sprintf("profile %s served from cache", profileID))
+	} else {
+		c.fetch(ctx, profileID, entry)
+	}
+
+	select {
+	case <-entry.done:
+		return entry.profile, entry.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// fetch gets the profile from the API and makes it available to the callers waiting for it.
+func (c *profileCache) fetch(ctx context.Context, profileID string, entry *cachedProfile) {
+	defer close(entry.done)
+
+	request := &nextdns.GetProfileRequest{
+		ProfileID: profileID,
+	}
+	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+
+	entry.profile, entry.err = c.client.Profiles.Get(ctx, request)
+	if entry.err != nil {
+		// Errors are not cached, so the next read tries again.
+		c.invalidateEntry(profileID, entry)
+	}
+}
+
+// invalidate drops the profile from the cache.
+func (c *profileCache) invalidate(profileID string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.entries, profileID)
+}
+
+// invalidateEntry drops the profile from the cache, unless it was already replaced by a newer fetch.
+func (c *profileCache) invalidateEntry(profileID string, entry *cachedProfile) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.entries[profileID] == entry {
+		delete(c.entries, profileID)
+	}
+}
+
+// profileCacheTransport represents a RoundTripper that drops a profile from the cache when a request changes it.
+type profileCacheTransport struct {
+	rt       http.RoundTripper
+	cache    *profileCache
+	basePath string
+}
+
+// RoundTrip sends the request, invalidating the cached profile once a request other than a read is done.
+// The profile is invalidated even if the request failed, as it might have been partially applied.
+func (t *profileCacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.rt.RoundTrip(req)
+
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		if profileID, ok := t.profileID(req); ok {
+			t.cache.invalidate(profileID)
+		}
+	}
+
+	return res, err
+}
+
+// profileID returns the ID of the profile targeted by the request, if any.
+func (t *profileCacheTransport) profileID(req *http.Request) (string, bool) {
+	path := strings.TrimPrefix(req.URL.Path, t.basePath)
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 2 || parts[0] != "profiles" || len(parts[1]) == 0 {
+		return "", false
+	}
+
+	return parts[1], true
+}
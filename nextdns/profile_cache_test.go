@@ -0,0 +1,121 @@
+package nextdns
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/amalucelli/nextdns-go/nextdns"
+	"github.com/amalucelli/terraform-provider-nextdns/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestProfileCache(t *testing.T) {
+	s := fakeapi.New()
+	defer s.Close()
+
+	meta, err := newProviderMeta(&clientConfig{apiKey: "test", apiURL: s.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profileID := s.CreateProfile(map[string]interface{}{"name": "test"})
+	ctx := context.Background()
+
+	countGets := func() int {
+		n := 0
+		for _, r := range s.Requests() {
+			if r == "GET /profiles/"+profileID {
+				n++
+			}
+		}
+		return n
+	}
+
+	// Concurrent reads of a same profile share a single request.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := meta.profiles.get(ctx, profileID); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := countGets(); n != 1 {
+		t.Fatalf("expected 1 request, got %d", n)
+	}
+
+	// A change of the profile invalidates the cache.
+	err = meta.client.Settings.Update(ctx, &nextdns.UpdateSettingsRequest{
+		ProfileID: profileID,
+		Settings:  &nextdns.Settings{Web3: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profile, err := meta.profiles.get(ctx, profileID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !profile.Settings.Web3 {
+		t.Error("expected the updated profile to be fetched")
+	}
+	if n := countGets(); n != 2 {
+		t.Fatalf("expected 2 requests, got %d", n)
+	}
+
+	// Errors are not cached.
+	for i := 0; i < 2; i++ {
+		if _, err := meta.profiles.get(ctx, "unknown"); !isNotFound(err) {
+			t.Fatalf("expected a not found error, got %v", err)
+		}
+	}
+	n := 0
+	for _, r := range s.Requests() {
+		if strings.HasSuffix(r, "/profiles/unknown") {
+			n++
+		}
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 requests for the unknown profile, got %d", n)
+	}
+}
+
+func TestProfileCache_missingSections(t *testing.T) {
+	s := fakeapi.New()
+	defer s.Close()
+
+	profileID := s.CreateProfile(map[string]interface{}{"name": "test"})
+	s.UpdateProfile(profileID, func(profile map[string]interface{}) {
+		for _, section := range []string{"security", "privacy", "settings", "parentalControl"} {
+			delete(profile, section)
+		}
+	})
+
+	meta, err := newProviderMeta(&clientConfig{apiKey: "test", apiURL: s.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A partial profile fails the read of the sections instead of crashing the provider.
+	for name, r := range map[string]*schema.Resource{
+		"nextdns_security":         resourceNextDNSSecurity(),
+		"nextdns_privacy":          resourceNextDNSPrivacy(),
+		"nextdns_settings":         resourceNextDNSSettings(),
+		"nextdns_parental_control": resourceNextDNSParentalControl(),
+	} {
+		d := r.Data(nil)
+		d.SetId(profileID)
+		d.Set("profile_id", profileID)
+
+		if diags := r.ReadContext(context.Background(), d, meta); !diags.HasError() {
+			t.Errorf("%s: expected an error, got %v", name, diags)
+		}
+	}
+}
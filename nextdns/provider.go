@@ -2,11 +2,14 @@ package nextdns
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strconv"
+	"time"
 
-	"github.com/amalucelli/nextdns-go/nextdns"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func Provider() *schema.Provider {
@@ -17,18 +20,77 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				Description: "NextDNS API Key",
 			},
+			"api_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "NextDNS API base URL. Can also be set with the NEXTDNS_API_URL environment variable.",
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Timeout for each attempt of a request to the NextDNS API (e.g. `30s`), the retries having their own timeout. Can also be set with the NEXTDNS_TIMEOUT environment variable.",
+				ValidateFunc: validateDuration,
+			},
+			"http_proxy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "URL of the HTTP proxy used to reach the NextDNS API. Can also be set with the NEXTDNS_HTTP_PROXY environment variable.",
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+			},
+			"ca_bundle": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to a PEM file with additional CA certificates to trust. Can also be set with the NEXTDNS_CA_BUNDLE environment variable.",
+			},
+			"headers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Extra headers added to every request to the NextDNS API. Can also be set with the NEXTDNS_HEADERS environment variable as a comma separated list of key=value pairs.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum number of retries for rate limited or failed requests, defaults to 3. Can also be set with the NEXTDNS_MAX_RETRIES environment variable.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Maximum time to wait between retries (e.g. `30s`), defaults to 30s. Can also be set with the NEXTDNS_RETRY_MAX_WAIT environment variable.",
+				ValidateFunc: validateDuration,
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"nextdns_setup_endpoint": dataSourceNextDNSSetupEndpoint(),
-			"nextdns_setup_linkedip": dataSourceNextDNSSetupLinkedIP(),
+			"nextdns_analytics_devices":        dataSourceNextDNSAnalyticsDevices(),
+			"nextdns_analytics_domains":        dataSourceNextDNSAnalyticsDomains(),
+			"nextdns_analytics_protocols":      dataSourceNextDNSAnalyticsProtocols(),
+			"nextdns_analytics_reasons":        dataSourceNextDNSAnalyticsReasons(),
+			"nextdns_analytics_status":         dataSourceNextDNSAnalyticsStatus(),
+			"nextdns_domain_verdict":           dataSourceNextDNSDomainVerdict(),
+			"nextdns_logs":                     dataSourceNextDNSLogs(),
+			"nextdns_parental_control_catalog": dataSourceNextDNSParentalControlCatalog(),
+			"nextdns_profile":                  dataSourceNextDNSProfile(),
+			"nextdns_profile_policy_check":     dataSourceNextDNSProfilePolicyCheck(),
+			"nextdns_profiles":                 dataSourceNextDNSProfiles(),
+			"nextdns_recreation_status":        dataSourceNextDNSRecreationStatus(),
+			"nextdns_setup_endpoint":           dataSourceNextDNSSetupEndpoint(),
+			"nextdns_setup_linkedip":           dataSourceNextDNSSetupLinkedIP(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"nextdns_allowlist":        resourceNextDNSAllowlist(),
+			"nextdns_allowlist_domain": resourceNextDNSAllowlistDomain(),
 			"nextdns_denylist":         resourceNextDNSDenylist(),
+			"nextdns_denylist_domain":  resourceNextDNSDenylistDomain(),
+			"nextdns_logs_purge":       resourceNextDNSLogsPurge(),
 			"nextdns_parental_control": resourceNextDNSParentalControl(),
 			"nextdns_privacy":          resourceNextDNSPrivacy(),
 			"nextdns_profile":          resourceNextDNSProfile(),
 			"nextdns_rewrite":          resourceNextDNSRewrite(),
+			"nextdns_rewrite_record":   resourceNextDNSRewriteRecord(),
 			"nextdns_security":         resourceNextDNSSecurity(),
 			"nextdns_settings":         resourceNextDNSSettings(),
 		},
@@ -38,11 +100,7 @@ func Provider() *schema.Provider {
 
 // nolint:revive
 func configure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	apiKey := os.Getenv("NEXTDNS_API_KEY")
-
-	if key, ok := d.Get("api_key").(string); ok && len(key) > 0 {
-		apiKey = key
-	}
+	apiKey := configOrEnv(d, "api_key", "NEXTDNS_API_KEY")
 
 	if len(apiKey) == 0 {
 		return nil, diag.Errorf(
@@ -50,10 +108,76 @@ func configure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.D
 		)
 	}
 
-	client, err := nextdns.New(nextdns.WithAPIKey(apiKey))
+	cfg := &clientConfig{
+		apiKey:   apiKey,
+		apiURL:   configOrEnv(d, "api_url", "NEXTDNS_API_URL"),
+		proxyURL: configOrEnv(d, "http_proxy", "NEXTDNS_HTTP_PROXY"),
+		caBundle: configOrEnv(d, "ca_bundle", "NEXTDNS_CA_BUNDLE"),
+
+		maxRetries:   defaultMaxRetries,
+		retryMaxWait: defaultRetryMaxWait,
+	}
+
+	if timeout := configOrEnv(d, "timeout", "NEXTDNS_TIMEOUT"); len(timeout) > 0 {
+		duration, err := time.ParseDuration(timeout)
+		if err != nil {
+			return nil, diag.Errorf("invalid timeout %q: %s", timeout, err)
+		}
+		cfg.timeout = duration
+	}
+
+	// GetOk reports zero as unset, while an explicit zero disables the retries.
+	if config := d.GetRawConfig(); !config.IsNull() && !config.GetAttr("max_retries").IsNull() {
+		cfg.maxRetries = d.Get("max_retries").(int)
+	} else if env := os.Getenv("NEXTDNS_MAX_RETRIES"); len(env) > 0 {
+		retries, err := strconv.Atoi(env)
+		if err != nil || retries < 0 {
+			return nil, diag.Errorf("invalid NEXTDNS_MAX_RETRIES %q: must be a non-negative integer", env)
+		}
+		cfg.maxRetries = retries
+	}
+
+	if maxWait := configOrEnv(d, "retry_max_wait", "NEXTDNS_RETRY_MAX_WAIT"); len(maxWait) > 0 {
+		duration, err := time.ParseDuration(maxWait)
+		if err != nil {
+			return nil, diag.Errorf("invalid retry_max_wait %q: %s", maxWait, err)
+		}
+		cfg.retryMaxWait = duration
+	}
+
+	headers, err := parseHeaders(os.Getenv("NEXTDNS_HEADERS"))
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
+	for k, v := range d.Get("headers").(map[string]interface{}) {
+		headers[k] = v.(string)
+	}
+	cfg.headers = headers
+
+	meta, err := newProviderMeta(cfg)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	return meta, nil
+}
+
+// configOrEnv returns the value of the provider argument, falling back to the environment variable when unset.
+func configOrEnv(d *schema.ResourceData, key string, env string) string {
+	value := os.Getenv(env)
+
+	if v, ok := d.Get(key).(string); ok && len(v) > 0 {
+		value = v
+	}
+
+	return value
+}
+
+// validateDuration validates that the value is a valid duration string.
+func validateDuration(v interface{}, k string) ([]string, []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%q must be a valid duration (e.g. 30s): %w", k, err)}
+	}
 
-	return client, nil
+	return nil, nil
 }
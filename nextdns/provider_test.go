@@ -0,0 +1,200 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/amalucelli/terraform-provider-nextdns/internal/fakeapi"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testAccProviderFactories runs the provider in-process for the acceptance tests.
+var testAccProviderFactories = map[string]func() (*schema.Provider, error){
+	"nextdns": func() (*schema.Provider, error) {
+		return Provider(), nil
+	},
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testAccFakeAPI starts a fake NextDNS API and points the provider to it.
+func testAccFakeAPI(t *testing.T) *fakeapi.Server {
+	t.Helper()
+
+	s := fakeapi.New()
+	t.Cleanup(s.Close)
+
+	t.Setenv("NEXTDNS_API_URL", s.URL)
+	t.Setenv("NEXTDNS_API_KEY", "test")
+
+	return s
+}
+
+// testAccCheckProfileDestroy checks that the profiles of the state were deleted from the fake API.
+func testAccCheckProfileDestroy(s *fakeapi.Server) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		for _, rs := range state.RootModule().Resources {
+			if rs.Type != "nextdns_profile" {
+				continue
+			}
+			if _, ok := s.Profile(rs.Primary.ID); ok {
+				return fmt.Errorf("profile %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckProfile checks the profile of the resource as stored by the fake API.
+func testAccCheckProfile(s *fakeapi.Server, name string, check func(profile map[string]interface{}) error) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+
+		profileID := rs.Primary.Attributes["profile_id"]
+		profile, ok := s.Profile(profileID)
+		if !ok {
+			return fmt.Errorf("profile %s not found", profileID)
+		}
+
+		return check(profile)
+	}
+}
+
+// testAccAddListEntry adds an entry to a list of every profile (e.g. denylist or security, tlds),
+// as if it was added outside of Terraform.
+func testAccAddListEntry(s *fakeapi.Server, entry map[string]interface{}, path ...string) {
+	for _, id := range s.ProfileIDs() {
+		s.UpdateProfile(id, func(profile map[string]interface{}) {
+			parent := profile
+			for _, p := range path[:len(path)-1] {
+				parent = parent[p].(map[string]interface{})
+			}
+
+			list := path[len(path)-1]
+			parent[list] = append(parent[list].([]interface{}), entry)
+		})
+	}
+}
+
+// testAccMarkRequests records the number of requests received by the fake API,
+// so testAccCheckWrites only considers the requests of the following step.
+func testAccMarkRequests(s *fakeapi.Server, mark *int) func() {
+	return func() {
+		*mark = len(s.Requests())
+	}
+}
+
+// testAccCheckWrites checks the requests modifying the profile of the resource since the mark,
+// given as "METHOD path" with the path relative to the profile (e.g. "PATCH parentalControl").
+func testAccCheckWrites(s *fakeapi.Server, name string, mark *int, want ...string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+
+		writes := []string{}
+		for _, r := range s.Requests()[*mark:] {
+			if !strings.HasPrefix(r, http.MethodGet+" ") {
+				writes = append(writes, r)
+			}
+		}
+
+		expected := make([]string, 0, len(want))
+		for _, w := range want {
+			method, path, _ := strings.Cut(w, " ")
+			expected = append(expected, method+" /profiles/"+rs.Primary.Attributes["profile_id"]+"/"+path)
+		}
+
+		if !reflect.DeepEqual(writes, expected) {
+			return fmt.Errorf("expected writes %v, got %v", expected, writes)
+		}
+
+		return nil
+	}
+}
+
+// testAccProfileConfig returns the configuration of the profile used by the other resources.
+func testAccProfileConfig() string {
+	return `
+resource "nextdns_profile" "test" {
+  name = "terraform-acc-test"
+}
+`
+}
+
+func TestProviderConfigure_maxRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	t.Setenv("NEXTDNS_API_KEY", "test")
+	t.Setenv("NEXTDNS_API_URL", server.URL)
+	t.Setenv("NEXTDNS_RETRY_MAX_WAIT", "1ms")
+
+	tests := []struct {
+		name      string
+		config    map[string]cty.Value
+		env       string
+		wantCalls int32
+	}{
+		{name: "default", wantCalls: defaultMaxRetries + 1},
+		{name: "argument", config: map[string]cty.Value{"max_retries": cty.NumberIntVal(1)}, env: "2", wantCalls: 2},
+		{name: "disabled", config: map[string]cty.Value{"max_retries": cty.NumberIntVal(0)}, env: "2", wantCalls: 1},
+		{name: "environment", env: "2", wantCalls: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NEXTDNS_MAX_RETRIES", tt.env)
+			atomic.StoreInt32(&calls, 0)
+
+			p := Provider()
+			block := schema.InternalMap(p.Schema).CoreConfigSchema()
+
+			// Every attribute is in the configuration sent by Terraform, the ones not set being null.
+			values := make(map[string]cty.Value)
+			for name, attributeType := range block.ImpliedType().AttributeTypes() {
+				values[name] = cty.NullVal(attributeType)
+				if v, ok := tt.config[name]; ok {
+					values[name] = v
+				}
+			}
+
+			// As the gRPC provider server does, the raw configuration is kept along the shimmed one.
+			config := terraform.NewResourceConfigShimmed(cty.ObjectVal(values), block)
+			config.CtyValue = cty.ObjectVal(values)
+
+			if diags := p.Configure(context.Background(), config); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if _, err := p.Meta().(*providerMeta).profiles.get(context.Background(), "abc123"); err == nil {
+				t.Fatal("expected an error")
+			}
+			if got := atomic.LoadInt32(&calls); got != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package nextdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"time"
+
+	// The IANA time zone database is embedded, so the time zones are validated the same way on every platform.
+	_ "time/tzdata"
+
+	"github.com/amalucelli/nextdns-go/nextdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// weekdays are the days of the week of the recreation windows, in order.
+var weekdays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// validateRecreationTime validates that the value is a time of the day in HH:MM:00 format.
+var validateRecreationTime = validation.StringMatch(regexp.MustCompile(`^([0-1][0-9]|2[0-3]):[0-5][0-9]:00$`), "Must be in HH:MM:00 format")
+
+var recreationTimeElem = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"start": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validateRecreationTime,
+		},
+		"end": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validateRecreationTime,
+		},
+	},
+}
+
+var recreationScheduleElem = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"days": {
+			Description: "The days of the week the window starts on (e.g. monday).",
+			Type:        schema.TypeSet,
+			Required:    true,
+			MinItems:    1,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(weekdays, false),
+			},
+		},
+		"start": {
+			Description:  "The start of the window, in HH:MM:00 format.",
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validateRecreationTime,
+		},
+		"end": {
+			Description:  "The end of the window, in HH:MM:00 format. It must be after the start, unless the window crosses midnight.",
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validateRecreationTime,
+		},
+		"crosses_midnight": {
+			Description: "Whether the window ends on the next day, its end being before its start (e.g. 22:00:00 to 01:00:00). " +
+				"The window belongs to the day it starts on.",
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+	},
+}
+
+// recreationDaySchema returns the schema of the window of a day of the week, superseded by the schedule blocks.
+func recreationDaySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem:     recreationTimeElem,
+		Deprecated: "Use the schedule blocks instead, which declare whether a window crosses midnight " +
+			"so that its start and end are checked.",
+	}
+}
+
+// validateTimezone validates that the value is a time zone of the IANA database (e.g. Europe/Paris).
+func validateTimezone(v interface{}, k string) ([]string, []error) {
+	timezone := v.(string)
+
+	// The empty name and Local are accepted by the time package, but are not time zones of the database.
+	if _, err := time.LoadLocation(timezone); err != nil || len(timezone) == 0 || timezone == "Local" {
+		// nolint:goerr113
+		return nil, []error{fmt.Errorf("%q must be a time zone of the IANA database (e.g. Europe/Paris), got %q", k, timezone)}
+	}
+
+	return nil, nil
+}
+
+// recreationInterval returns the window of the day of the week.
+func recreationInterval(times *nextdns.ParentalControlRecreationTimes, day string) **nextdns.ParentalControlRecreationInterval {
+	switch day {
+	case "monday":
+		return &times.Monday
+	case "tuesday":
+		return &times.Tuesday
+	case "wednesday":
+		return &times.Wednesday
+	case "thursday":
+		return &times.Thursday
+	case "friday":
+		return &times.Friday
+	case "saturday":
+		return &times.Saturday
+	default:
+		return &times.Sunday
+	}
+}
+
+// buildRecreation returns the recreation settings of a recreation block, either declared day by day or by schedules.
+func buildRecreation(v interface{}) (*nextdns.ParentalControlRecreation, error) {
+	blocks, _ := v.([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return &nextdns.ParentalControlRecreation{}, nil
+	}
+	block := blocks[0].(map[string]interface{})
+
+	times := &nextdns.ParentalControlRecreationTimes{}
+	days := false
+	for _, day := range weekdays {
+		intervals, _ := block[day].([]interface{})
+		if len(intervals) == 0 || intervals[0] == nil {
+			continue
+		}
+		interval := intervals[0].(map[string]interface{})
+
+		// The end of a day block may precede its start, the window crossing midnight.
+		if start := interval["start"].(string); len(start) > 0 && start == interval["end"].(string) {
+			// nolint:goerr113
+			return nil, fmt.Errorf("%s: the start %s and the end of the window must differ", day, start)
+		}
+
+		*recreationInterval(times, day) = &nextdns.ParentalControlRecreationInterval{
+			Start: interval["start"].(string),
+			End:   interval["end"].(string),
+		}
+		days = true
+	}
+
+	schedules, _ := block["schedule"].([]interface{})
+	if days && len(schedules) > 0 {
+		// nolint:goerr113
+		return nil, errors.New("the schedule blocks cannot be combined with the day blocks of the recreation")
+	}
+
+	for i, s := range schedules {
+		schedule := s.(map[string]interface{})
+		if err := addRecreationSchedule(times, schedule); err != nil {
+			return nil, fmt.Errorf("schedule %d: %w", i, err)
+		}
+	}
+
+	return &nextdns.ParentalControlRecreation{
+		Times:    times,
+		Timezone: block["timezone"].(string),
+	}, nil
+}
+
+// addRecreationSchedule adds the windows of a schedule to the windows of the week.
+func addRecreationSchedule(times *nextdns.ParentalControlRecreationTimes, schedule map[string]interface{}) error {
+	start := schedule["start"].(string)
+	end := schedule["end"].(string)
+	crossesMidnight := schedule["crosses_midnight"].(bool)
+
+	switch {
+	case len(start) == 0 || len(end) == 0:
+		// The times are not known yet when planning.
+	case !crossesMidnight && start >= end:
+		// nolint:goerr113
+		return fmt.Errorf("the start %s must precede the end %s, unless crosses_midnight is set", start, end)
+	case crossesMidnight && start <= end:
+		// nolint:goerr113
+		return fmt.Errorf("the end %s must precede the start %s, as the window crosses midnight", end, start)
+	}
+
+	days := schedule["days"].(*schema.Set)
+	for _, day := range weekdays {
+		if !days.Contains(day) {
+			continue
+		}
+
+		current := recreationInterval(times, day)
+		if *current != nil {
+			// nolint:goerr113
+			return fmt.Errorf("%s already has a recreation window, and only one window per day is supported", day)
+		}
+		*current = &nextdns.ParentalControlRecreationInterval{
+			Start: start,
+			End:   end,
+		}
+	}
+
+	return nil
+}
+
+// flattenRecreation returns the recreation block of the recreation settings. When the current recreation block
+// declares schedules, they are kept as long as they match the settings, as several schedules describe the same windows.
+func flattenRecreation(recreation *nextdns.ParentalControlRecreation, current interface{}) map[string]interface{} {
+	values := map[string]interface{}{
+		"timezone": recreation.Timezone,
+	}
+
+	times := recreation.Times
+	if times == nil {
+		times = &nextdns.ParentalControlRecreationTimes{}
+	}
+
+	blocks, _ := current.([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		for _, day := range weekdays {
+			if interval := *recreationInterval(times, day); interval != nil {
+				values[day] = []map[string]interface{}{
+					{
+						"start": interval.Start,
+						"end":   interval.End,
+					},
+				}
+			}
+		}
+
+		return values
+	}
+
+	schedules, _ := blocks[0].(map[string]interface{})["schedule"].([]interface{})
+	if len(schedules) == 0 {
+		return flattenRecreation(recreation, nil)
+	}
+
+	if declared, err := buildRecreation(current); err == nil && reflect.DeepEqual(declared.Times, times) {
+		values["schedule"] = schedules
+		return values
+	}
+
+	values["schedule"] = flattenRecreationSchedules(times)
+
+	return values
+}
+
+// flattenRecreationSchedules returns the schedule blocks of the windows of the week, grouping the days with the same window.
+func flattenRecreationSchedules(times *nextdns.ParentalControlRecreationTimes) []interface{} {
+	type window struct {
+		start string
+		end   string
+	}
+
+	var windows []window
+	days := make(map[window][]interface{})
+	for _, day := range weekdays {
+		interval := *recreationInterval(times, day)
+		if interval == nil {
+			continue
+		}
+
+		w := window{start: interval.Start, end: interval.End}
+		if _, ok := days[w]; !ok {
+			windows = append(windows, w)
+		}
+		days[w] = append(days[w], day)
+	}
+	sort.SliceStable(windows, func(i, j int) bool {
+		return windows[i].start < windows[j].start
+	})
+
+	schedules := make([]interface{}, 0, len(windows))
+	for _, w := range windows {
+		schedules = append(schedules, map[string]interface{}{
+			"days":             days[w],
+			"start":            w.start,
+			"end":              w.end,
+			"crosses_midnight": w.end < w.start,
+		})
+	}
+
+	return schedules
+}
+
+// customizeDiffRecreation returns the function validating the recreation windows of the recreation block when planning.
+func customizeDiffRecreation(key string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+
+		if _, err := buildRecreation(d.Get(key)); err != nil {
+			return fmt.Errorf("invalid recreation of %s: %w", key, err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,142 @@
+package nextdns
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/amalucelli/nextdns-go/nextdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testRecreationBlock(schedules ...map[string]interface{}) []interface{} {
+	list := make([]interface{}, 0, len(schedules))
+	for _, s := range schedules {
+		if _, ok := s["crosses_midnight"]; !ok {
+			s["crosses_midnight"] = false
+		}
+		s["days"] = schema.NewSet(schema.HashString, s["days"].([]interface{}))
+		list = append(list, s)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"timezone": "Europe/Paris",
+			"schedule": list,
+		},
+	}
+}
+
+func TestBuildRecreation(t *testing.T) {
+	recreation, err := buildRecreation(testRecreationBlock(
+		map[string]interface{}{"days": []interface{}{"monday", "wednesday"}, "start": "16:00:00", "end": "18:00:00"},
+		map[string]interface{}{"days": []interface{}{"sunday"}, "start": "22:00:00", "end": "01:00:00", "crosses_midnight": true},
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &nextdns.ParentalControlRecreationTimes{
+		Monday:    &nextdns.ParentalControlRecreationInterval{Start: "16:00:00", End: "18:00:00"},
+		Wednesday: &nextdns.ParentalControlRecreationInterval{Start: "16:00:00", End: "18:00:00"},
+		Sunday:    &nextdns.ParentalControlRecreationInterval{Start: "22:00:00", End: "01:00:00"},
+	}
+	if !reflect.DeepEqual(recreation.Times, want) || recreation.Timezone != "Europe/Paris" {
+		t.Errorf("unexpected recreation %+v", recreation)
+	}
+}
+
+func TestBuildRecreationDays(t *testing.T) {
+	recreation, err := buildRecreation([]interface{}{
+		map[string]interface{}{
+			"timezone": "Europe/Paris",
+			"monday":   []interface{}{map[string]interface{}{"start": "16:00:00", "end": "18:00:00"}},
+			"friday":   []interface{}{map[string]interface{}{"start": "22:00:00", "end": "01:00:00"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &nextdns.ParentalControlRecreationTimes{
+		Monday: &nextdns.ParentalControlRecreationInterval{Start: "16:00:00", End: "18:00:00"},
+		Friday: &nextdns.ParentalControlRecreationInterval{Start: "22:00:00", End: "01:00:00"},
+	}
+	if !reflect.DeepEqual(recreation.Times, want) {
+		t.Errorf("unexpected recreation %+v", recreation)
+	}
+}
+
+func TestBuildRecreationInvalid(t *testing.T) {
+	tests := map[string][]interface{}{
+		"start after end": testRecreationBlock(
+			map[string]interface{}{"days": []interface{}{"monday"}, "start": "18:00:00", "end": "16:00:00"},
+		),
+		"end after start crossing midnight": testRecreationBlock(
+			map[string]interface{}{"days": []interface{}{"monday"}, "start": "16:00:00", "end": "18:00:00", "crosses_midnight": true},
+		),
+		"same day twice": testRecreationBlock(
+			map[string]interface{}{"days": []interface{}{"monday", "tuesday"}, "start": "16:00:00", "end": "18:00:00"},
+			map[string]interface{}{"days": []interface{}{"tuesday"}, "start": "20:00:00", "end": "21:00:00"},
+		),
+	}
+
+	tests["empty day window"] = []interface{}{
+		map[string]interface{}{
+			"timezone": "Europe/Paris",
+			"monday":   []interface{}{map[string]interface{}{"start": "16:00:00", "end": "16:00:00"}},
+		},
+	}
+
+	for name, block := range tests {
+		if _, err := buildRecreation(block); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestFlattenRecreation(t *testing.T) {
+	current := testRecreationBlock(
+		map[string]interface{}{"days": []interface{}{"monday"}, "start": "16:00:00", "end": "18:00:00"},
+		map[string]interface{}{"days": []interface{}{"tuesday"}, "start": "16:00:00", "end": "18:00:00"},
+	)
+	recreation, err := buildRecreation(current)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The declared schedules are kept while they match the settings.
+	values := flattenRecreation(recreation, current)
+	if !reflect.DeepEqual(values["schedule"], current[0].(map[string]interface{})["schedule"]) {
+		t.Errorf("unexpected schedules %v", values["schedule"])
+	}
+
+	// The settings changed outside of Terraform are grouped by window.
+	recreation.Times.Friday = &nextdns.ParentalControlRecreationInterval{Start: "20:00:00", End: "01:00:00"}
+	values = flattenRecreation(recreation, current)
+	want := []interface{}{
+		map[string]interface{}{"days": []interface{}{"monday", "tuesday"}, "start": "16:00:00", "end": "18:00:00", "crosses_midnight": false},
+		map[string]interface{}{"days": []interface{}{"friday"}, "start": "20:00:00", "end": "01:00:00", "crosses_midnight": true},
+	}
+	if !reflect.DeepEqual(values["schedule"], want) {
+		t.Errorf("flattenRecreation() = %v, want %v", values["schedule"], want)
+	}
+
+	// Without schedules, the windows are described day by day.
+	values = flattenRecreation(recreation, nil)
+	if _, ok := values["friday"]; !ok {
+		t.Errorf("unexpected day blocks %v", values)
+	}
+}
+
+func TestValidateTimezone(t *testing.T) {
+	for _, tz := range []string{"Europe/Paris", "America/New_York", "UTC"} {
+		if _, errs := validateTimezone(tz, "timezone"); len(errs) > 0 {
+			t.Errorf("validateTimezone(%q) returned %v", tz, errs)
+		}
+	}
+	for _, tz := range []string{"", "Local", "Europe/Atlantis", "+02:00"} {
+		if _, errs := validateTimezone(tz, "timezone"); len(errs) == 0 {
+			t.Errorf("validateTimezone(%q) did not return an error", tz)
+		}
+	}
+}
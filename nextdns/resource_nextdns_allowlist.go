@@ -8,6 +8,7 @@ import (
 	"github.com/amalucelli/nextdns-go/nextdns"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -18,6 +19,10 @@ func resourceNextDNSAllowlist() *schema.Resource {
 		ReadContext:   resourceNextDNSAllowlistRead,
 		UpdateContext: resourceNextDNSAllowlistUpdate,
 		DeleteContext: resourceNextDNSAllowlistDelete,
+		CustomizeDiff: customdiff.Sequence(
+			customizeDiffDomainSources(true),
+			customizeDiffExpiredDomains,
+		),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceNextDNSAllowlistImport,
 		},
@@ -25,24 +30,35 @@ func resourceNextDNSAllowlist() *schema.Resource {
 }
 
 func resourceNextDNSAllowlistCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
+	if err := applyExpiredDomains(d); err != nil {
+		return diag.FromErr(fmt.Errorf("error evaluating the expiry of the allow list: %w", err))
+	}
+
 	allowlist, err := buildAllowlist(d)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error building allow list: %w", err))
 	}
 	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", allowlist))
 
-	request := &nextdns.CreateAllowlistRequest{
-		ProfileID: profileID,
-		Allowlist: allowlist,
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+	if d.Get("authoritative").(bool) {
+		request := &nextdns.CreateAllowlistRequest{
+			ProfileID: profileID,
+			Allowlist: allowlist,
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
 
-	err = client.Allowlist.Create(ctx, request)
+		err = client.Allowlist.Create(ctx, request)
+	} else {
+		err = syncAllowlist(ctx, meta.(*providerMeta), profileID, nil, declaredDomains(d.Get("domain"), d.Get("source_domain"), d.Get("expired_domains")))
+	}
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error creating allow list: %w", err))
+		return apiErrorDiags(err, "error creating allow list", setErrorTarget("", d, "domain", "id"))
 	}
 
 	d.SetId(profileID)
@@ -51,30 +67,50 @@ func resourceNextDNSAllowlistCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceNextDNSAllowlistRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
-	profileID := d.Get("profile_id").(string)
-
-	request := &nextdns.ListAllowlistRequest{
-		ProfileID: profileID,
+	if diags := readAllowlist(ctx, d, meta); diags.HasError() || len(d.Id()) == 0 {
+		return diags
 	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
 
-	allowlist, err := client.Allowlist.List(ctx, request)
+	return refreshExpiry(d)
+}
+
+// readAllowlist reads the allow list, without evaluating the expiry times again as the apply must keep the planned ones.
+func readAllowlist(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	profiles := meta.(*providerMeta).profiles
+	profileID := d.Get("profile_id").(string)
+
+	profile, err := profiles.get(ctx, profileID)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error getting allow list: %w", err))
+		if isNotFound(err) {
+			return removeNotFound(ctx, d, "nextdns_allowlist")
+		}
+		return apiErrorDiags(err, "error getting allow list")
 	}
+	allowlist := profile.Allowlist
 	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", allowlist))
 
-	var domains []map[string]interface{}
-
-	for _, d := range allowlist {
-		domain := make(map[string]interface{})
-		domain["id"] = d.ID
-		domain["active"] = d.Active
+	// The domains loaded from the sources are kept apart from the declared ones. When the resource is not authoritative,
+	// the domains added outside of Terraform are ignored.
+	authoritative := d.Get("authoritative").(bool)
+	declared := domainsFromSet(d.Get("domain"))
+	sources := domainsFromSet(d.Get("source_domain"))
+
+	var domains, sourced []*nextdns.Allowlist
+	for _, e := range allowlist {
+		_, isDeclared := declared[e.ID]
+		_, isSourced := sources[e.ID]
+		switch {
+		case isSourced && !isDeclared:
+			sourced = append(sourced, e)
+		case isDeclared || authoritative:
+			domains = append(domains, e)
+		}
+	}
 
-		domains = append(domains, domain)
+	if err := d.Set("domain", withExpiry(flattenAllowlist(domains), d.Get("domain"), d.Get("expired_domains"))); err != nil {
+		return diag.FromErr(err)
 	}
-	if err := d.Set("domain", domains); err != nil {
+	if err := d.Set("source_domain", flattenAllowlist(sourced)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -84,73 +120,149 @@ func resourceNextDNSAllowlistRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceNextDNSAllowlistUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
+	if err := applyExpiredDomains(d); err != nil {
+		return diag.FromErr(fmt.Errorf("error evaluating the expiry of the allow list: %w", err))
+	}
+
 	allowlist, err := buildAllowlist(d)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error building allow list: %w", err))
 	}
 	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", allowlist))
 
-	request := &nextdns.CreateAllowlistRequest{
-		ProfileID: profileID,
-		Allowlist: allowlist,
+	if d.Get("authoritative").(bool) {
+		request := &nextdns.CreateAllowlistRequest{
+			ProfileID: profileID,
+			Allowlist: allowlist,
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+
+		err = client.Allowlist.Create(ctx, request)
+	} else {
+		previousDomain, desiredDomain := d.GetChange("domain")
+		previousSource, desiredSource := d.GetChange("source_domain")
+		previousExpired, desiredExpired := d.GetChange("expired_domains")
+		err = syncAllowlist(ctx, meta.(*providerMeta), profileID, declaredDomains(previousDomain, previousSource, previousExpired), declaredDomains(desiredDomain, desiredSource, desiredExpired))
 	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
-
-	err = client.Allowlist.Create(ctx, request)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error updating allow list: %w", err))
+		return apiErrorDiags(err, "error updating allow list", setErrorTarget("", d, "domain", "id"))
 	}
 
-	return resourceNextDNSAllowlistRead(ctx, d, meta)
+	return readAllowlist(ctx, d, meta)
 }
 
 func resourceNextDNSAllowlistDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
-	request := &nextdns.CreateAllowlistRequest{
-		ProfileID: profileID,
-		Allowlist: []*nextdns.Allowlist{},
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
+	var err error
+	if d.Get("authoritative").(bool) {
+		request := &nextdns.CreateAllowlistRequest{
+			ProfileID: profileID,
+			Allowlist: []*nextdns.Allowlist{},
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
 
-	err := client.Allowlist.Create(ctx, request)
+		err = client.Allowlist.Create(ctx, request)
+	} else {
+		err = syncAllowlist(ctx, meta.(*providerMeta), profileID, declaredDomains(d.Get("domain"), d.Get("source_domain"), d.Get("expired_domains")), nil)
+	}
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error deleting allow list: %w", err))
+		if isNotFound(err) {
+			return nil
+		}
+		return apiErrorDiags(err, "error deleting allow list")
 	}
 
-	return resourceNextDNSAllowlistRead(ctx, d, meta)
+	return readAllowlist(ctx, d, meta)
 }
 
 func resourceNextDNSAllowlistImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	profileID := d.Id()
-	d.SetId(profileID)
-	d.Set("profile_id", profileID)
+	return importDomainList(ctx, d, meta, resourceNextDNSAllowlistRead)
+}
+
+// flattenAllowlist returns the domain blocks of the entries.
+func flattenAllowlist(allowlist []*nextdns.Allowlist) []map[string]interface{} {
+	var domains []map[string]interface{}
 
-	resourceNextDNSAllowlistRead(ctx, d, meta)
+	for _, e := range allowlist {
+		domain := make(map[string]interface{})
+		domain["id"] = e.ID
+		domain["active"] = e.Active
+
+		domains = append(domains, domain)
+	}
 
-	return []*schema.ResourceData{d}, nil
+	return domains
 }
 
-func buildAllowlist(d *schema.ResourceData) ([]*nextdns.Allowlist, error) {
+func buildAllowlist(d resourceData) ([]*nextdns.Allowlist, error) {
 	found, ok := d.GetOk("domain")
-	if !ok {
+	_, hasSource := d.GetOk("source")
+	if !ok && !hasSource {
 		// nolint:goerr113
 		return nil, errors.New("unable to find domain in resource data")
 	}
 
-	records := found.(*schema.Set).List()
+	var records []interface{}
+	if ok {
+		records = found.(*schema.Set).List()
+	}
 
-	allowlist := make([]*nextdns.Allowlist, len(records))
-	for k, v := range records {
-		allowlist[k] = &nextdns.Allowlist{
-			ID:     v.(map[string]interface{})["id"].(string),
+	allowlist := make([]*nextdns.Allowlist, 0, len(records))
+	// The expired domains are removed from the list.
+	expired := toSet(stringsFromSet(d.Get("expired_domains")))
+	for _, v := range records {
+		if expired[normalizeDomain(v.(map[string]interface{})["id"].(string))] {
+			continue
+		}
+		allowlist = append(allowlist, &nextdns.Allowlist{
+			ID:     normalizeDomain(v.(map[string]interface{})["id"].(string)),
 			Active: v.(map[string]interface{})["active"].(bool),
+		})
+	}
+
+	// The domains loaded from the sources are sent after the declared ones, which take precedence.
+	declared := domainsFromSet(found)
+	sources := domainsFromSet(d.Get("source_domain"))
+	for _, id := range sortedKeys(sources) {
+		if _, ok := declared[id]; !ok {
+			allowlist = append(allowlist, &nextdns.Allowlist{
+				ID:     id,
+				Active: sources[id],
+			})
 		}
 	}
 
 	return allowlist, nil
 }
+
+// syncAllowlist adds, updates and removes only the declared domains of the allow list,
+// leaving the domains added outside of Terraform untouched.
+func syncAllowlist(ctx context.Context, m *providerMeta, profileID string, previous, desired map[string]bool) error {
+	request := &nextdns.ListAllowlistRequest{
+		ProfileID: profileID,
+	}
+	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+
+	allowlist, err := m.client.Allowlist.List(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool, len(allowlist))
+	for _, e := range allowlist {
+		existing[e.ID] = e.Active
+	}
+
+	return syncDomainList(ctx, m.api, profileID, allowlistAPIPath, existing, previous, desired)
+}
@@ -0,0 +1,134 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/amalucelli/nextdns-go/nextdns"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// allowlistAPIPath is the path of the allowlist within a profile.
+const allowlistAPIPath = "allowlist"
+
+func resourceNextDNSAllowlistDomain() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceNextDNSAllowlistDomainSchema(),
+		CreateContext: resourceNextDNSAllowlistDomainCreate,
+		ReadContext:   resourceNextDNSAllowlistDomainRead,
+		UpdateContext: resourceNextDNSAllowlistDomainUpdate,
+		DeleteContext: resourceNextDNSAllowlistDomainDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceNextDNSAllowlistDomainImport,
+		},
+	}
+}
+
+func resourceNextDNSAllowlistDomainCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	api := meta.(*providerMeta).api
+	profileID := d.Get("profile_id").(string)
+	domain := normalizeDomain(d.Get("domain").(string))
+
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
+	entry := &nextdns.Allowlist{
+		ID:     domain,
+		Active: d.Get("active").(bool),
+	}
+	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", entry))
+
+	err := api.addListEntry(ctx, profileID, allowlistAPIPath, entry)
+	if err != nil {
+		return apiErrorDiags(err, "error creating allow list domain", fieldErrorTarget("/id", d, "domain"))
+	}
+
+	d.SetId(profileID + "/" + domain)
+
+	return resourceNextDNSAllowlistDomainRead(ctx, d, meta)
+}
+
+func resourceNextDNSAllowlistDomainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	profiles := meta.(*providerMeta).profiles
+	profileID := d.Get("profile_id").(string)
+	domain := normalizeDomain(d.Get("domain").(string))
+
+	profile, err := profiles.get(ctx, profileID)
+	if err != nil {
+		if isNotFound(err) {
+			return removeNotFound(ctx, d, "nextdns_allowlist_domain")
+		}
+		return apiErrorDiags(err, "error getting allow list")
+	}
+	allowlist := profile.Allowlist
+	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", allowlist))
+
+	var entry *nextdns.Allowlist
+	for _, e := range allowlist {
+		if e.ID == domain {
+			entry = e
+			break
+		}
+	}
+
+	if entry == nil {
+		return removeFromState(ctx, d,
+			"nextdns_allowlist_domain not found",
+			fmt.Sprintf("The domain %q is no longer in the allow list of the profile %q, so it was removed from the state and will be recreated on the next apply.", domain, profileID),
+		)
+	}
+
+	d.Set("active", entry.Active)
+
+	return nil
+}
+
+func resourceNextDNSAllowlistDomainUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	profileID := d.Get("profile_id").(string)
+	domain := normalizeDomain(d.Get("domain").(string))
+
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
+	request := &nextdns.UpdateAllowlistRequest{
+		ProfileID: profileID,
+		ID:        domain,
+		Allowlist: &nextdns.Allowlist{
+			Active: d.Get("active").(bool),
+		},
+	}
+	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+
+	err := client.Allowlist.Update(ctx, request)
+	if err != nil {
+		return apiErrorDiags(err, "error updating allow list domain")
+	}
+
+	return resourceNextDNSAllowlistDomainRead(ctx, d, meta)
+}
+
+func resourceNextDNSAllowlistDomainDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	api := meta.(*providerMeta).api
+	profileID := d.Get("profile_id").(string)
+	domain := normalizeDomain(d.Get("domain").(string))
+
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
+	err := api.deleteListEntry(ctx, profileID, allowlistAPIPath, domain)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return apiErrorDiags(err, "error deleting allow list domain")
+	}
+
+	return nil
+}
+
+func resourceNextDNSAllowlistDomainImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	return importListDomain(ctx, d, meta, resourceNextDNSAllowlistDomainRead)
+}
@@ -0,0 +1,94 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/amalucelli/terraform-provider-nextdns/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNextDNSAllowlistDomain_basic(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNextDNSAllowlistDomainConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_allowlist_domain.test", "domain", "example.com"),
+					resource.TestCheckResourceAttr("nextdns_allowlist_domain.test", "active", "true"),
+				),
+			},
+			{
+				Config: testAccNextDNSAllowlistDomainConfig(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_allowlist_domain.test", "active", "false"),
+					testAccCheckProfile(s, "nextdns_allowlist_domain.test", func(profile map[string]interface{}) error {
+						allowlist := profile["allowlist"].([]interface{})
+						if len(allowlist) != 1 || allowlist[0].(map[string]interface{})["active"] != false {
+							return fmt.Errorf("unexpected allow list %v", allowlist)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:      "nextdns_allowlist_domain.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNextDNSAllowlistDomainConfig(active bool) string {
+	return testAccProfileConfig() + fmt.Sprintf(`
+resource "nextdns_allowlist_domain" "test" {
+  profile_id = nextdns_profile.test.id
+  domain     = "example.com"
+  active     = %t
+}
+`, active)
+}
+
+func TestResourceNextDNSAllowlistDomainImport(t *testing.T) {
+	s := fakeapi.New()
+	defer s.Close()
+
+	meta, err := newProviderMeta(&clientConfig{apiKey: "test", apiURL: s.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profileID := s.CreateProfile(map[string]interface{}{
+		"name":      "test",
+		"allowlist": []interface{}{map[string]interface{}{"id": "example.com", "active": true}},
+	})
+
+	tests := []struct {
+		id      string
+		wantErr bool
+	}{
+		{id: profileID + "/Example.COM", wantErr: false},
+		{id: profileID + "/example.org", wantErr: true},
+		{id: "unknown/example.com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		r := resourceNextDNSAllowlistDomain()
+		d := r.Data(nil)
+		d.SetId(tt.id)
+
+		_, err := r.Importer.StateContext(context.Background(), d, meta)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("import of %s: unexpected error %v", tt.id, err)
+		}
+		if err == nil && (d.Id() != profileID+"/example.com" || !d.Get("active").(bool)) {
+			t.Errorf("import of %s: unexpected state %s %v", tt.id, d.Id(), d.Get("active"))
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/amalucelli/terraform-provider-nextdns/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccNextDNSAllowlist_basic(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNextDNSAllowlistConfig(true, "example.com", "example.org"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_allowlist.test", "domain.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("nextdns_allowlist.test", "domain.*", map[string]string{
+						"id":     "example.com",
+						"active": "true",
+					}),
+				),
+			},
+			{
+				Config: testAccNextDNSAllowlistConfig(true, "example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_allowlist.test", "domain.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "nextdns_allowlist.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// An imported list is not authoritative until the configuration makes it so.
+				ImportStateVerifyIgnore: []string{"authoritative"},
+			},
+		},
+	})
+}
+
+func TestAccNextDNSAllowlist_nonAuthoritative(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNextDNSAllowlistConfig(false, "example.com"),
+			},
+			{
+				// A domain added outside of Terraform must be kept when the resource is not authoritative.
+				PreConfig: func() {
+					testAccAddListEntry(s, map[string]interface{}{"id": "other.com", "active": true}, "allowlist")
+				},
+				Config: testAccNextDNSAllowlistConfig(false, "example.org"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_allowlist.test", "domain.#", "1"),
+					testAccCheckProfile(s, "nextdns_allowlist.test", func(profile map[string]interface{}) error {
+						allowlist := profile["allowlist"].([]interface{})
+						if len(allowlist) != 2 {
+							return fmt.Errorf("unexpected allow list %v", allowlist)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccNextDNSAllowlistConfig(authoritative bool, domains ...string) string {
+	config := testAccProfileConfig() + fmt.Sprintf(`
+resource "nextdns_allowlist" "test" {
+  profile_id    = nextdns_profile.test.id
+  authoritative = %t
+`, authoritative)
+
+	for _, domain := range domains {
+		config += fmt.Sprintf(`
+  domain {
+    id     = %q
+    active = true
+  }
+`, domain)
+	}
+
+	return config + "}\n"
+}
+
+func TestResourceNextDNSAllowlistImport(t *testing.T) {
+	s := fakeapi.New()
+	defer s.Close()
+
+	meta, err := newProviderMeta(&clientConfig{apiKey: "test", apiURL: s.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profileID := s.CreateProfile(map[string]interface{}{
+		"name": "test",
+		"allowlist": []interface{}{
+			map[string]interface{}{"id": "example.com", "active": true},
+			map[string]interface{}{"id": "example.org", "active": false},
+		},
+	})
+
+	r := resourceNextDNSAllowlist()
+	d := r.Data(nil)
+	d.SetId(profileID)
+
+	if _, err := r.Importer.StateContext(context.Background(), d, meta); err != nil {
+		t.Fatal(err)
+	}
+	if d.Get("authoritative").(bool) {
+		t.Error("expected the imported list not to be authoritative")
+	}
+
+	// The imported domains are kept by the following refreshes, though the list is not authoritative.
+	if diags := r.ReadContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if n := d.Get("domain").(*schema.Set).Len(); n != 2 {
+		t.Errorf("imported %d domains, want 2", n)
+	}
+
+	d = r.Data(nil)
+	d.SetId("unknown")
+	if _, err := r.Importer.StateContext(context.Background(), d, meta); err == nil {
+		t.Error("expected an error for an unknown profile")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/amalucelli/nextdns-go/nextdns"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -18,6 +19,10 @@ func resourceNextDNSDenylist() *schema.Resource {
 		ReadContext:   resourceNextDNSDenylistRead,
 		UpdateContext: resourceNextDNSDenylistUpdate,
 		DeleteContext: resourceNextDNSDenylistDelete,
+		CustomizeDiff: customdiff.Sequence(
+			customizeDiffDomainSources(false),
+			customizeDiffExpiredDomains,
+		),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceNextDNSDenylistImport,
 		},
@@ -25,24 +30,35 @@ func resourceNextDNSDenylist() *schema.Resource {
 }
 
 func resourceNextDNSDenylistCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
+	if err := applyExpiredDomains(d); err != nil {
+		return diag.FromErr(fmt.Errorf("error evaluating the expiry of the deny list: %w", err))
+	}
+
 	denylist, err := buildDenylist(d)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error building deny list: %w", err))
 	}
 	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", denylist))
 
-	request := &nextdns.CreateDenylistRequest{
-		ProfileID: profileID,
-		Denylist:  denylist,
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+	if d.Get("authoritative").(bool) {
+		request := &nextdns.CreateDenylistRequest{
+			ProfileID: profileID,
+			Denylist:  denylist,
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
 
-	err = client.Denylist.Create(ctx, request)
+		err = client.Denylist.Create(ctx, request)
+	} else {
+		err = syncDenylist(ctx, meta.(*providerMeta), profileID, nil, declaredDomains(d.Get("domain"), d.Get("source_domain"), d.Get("expired_domains")))
+	}
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error creating deny list: %w", err))
+		return apiErrorDiags(err, "error creating deny list", setErrorTarget("", d, "domain", "id"))
 	}
 
 	d.SetId(profileID)
@@ -51,30 +67,50 @@ func resourceNextDNSDenylistCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceNextDNSDenylistRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
-	profileID := d.Get("profile_id").(string)
-
-	request := &nextdns.ListDenylistRequest{
-		ProfileID: profileID,
+	if diags := readDenylist(ctx, d, meta); diags.HasError() || len(d.Id()) == 0 {
+		return diags
 	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
 
-	denylist, err := client.Denylist.List(ctx, request)
+	return refreshExpiry(d)
+}
+
+// readDenylist reads the deny list, without evaluating the expiry times again as the apply must keep the planned ones.
+func readDenylist(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	profiles := meta.(*providerMeta).profiles
+	profileID := d.Get("profile_id").(string)
+
+	profile, err := profiles.get(ctx, profileID)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error getting deny list: %w", err))
+		if isNotFound(err) {
+			return removeNotFound(ctx, d, "nextdns_denylist")
+		}
+		return apiErrorDiags(err, "error getting deny list")
 	}
+	denylist := profile.Denylist
 	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", denylist))
 
-	var domains []map[string]interface{}
-
-	for _, d := range denylist {
-		domain := make(map[string]interface{})
-		domain["id"] = d.ID
-		domain["active"] = d.Active
+	// The domains loaded from the sources are kept apart from the declared ones. When the resource is not authoritative,
+	// the domains added outside of Terraform are ignored.
+	authoritative := d.Get("authoritative").(bool)
+	declared := domainsFromSet(d.Get("domain"))
+	sources := domainsFromSet(d.Get("source_domain"))
+
+	var domains, sourced []*nextdns.Denylist
+	for _, e := range denylist {
+		_, isDeclared := declared[e.ID]
+		_, isSourced := sources[e.ID]
+		switch {
+		case isSourced && !isDeclared:
+			sourced = append(sourced, e)
+		case isDeclared || authoritative:
+			domains = append(domains, e)
+		}
+	}
 
-		domains = append(domains, domain)
+	if err := d.Set("domain", withExpiry(flattenDenylist(domains), d.Get("domain"), d.Get("expired_domains"))); err != nil {
+		return diag.FromErr(err)
 	}
-	if err := d.Set("domain", domains); err != nil {
+	if err := d.Set("source_domain", flattenDenylist(sourced)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -84,73 +120,149 @@ func resourceNextDNSDenylistRead(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceNextDNSDenylistUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
+	if err := applyExpiredDomains(d); err != nil {
+		return diag.FromErr(fmt.Errorf("error evaluating the expiry of the deny list: %w", err))
+	}
+
 	denylist, err := buildDenylist(d)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error building deny list: %w", err))
 	}
 	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", denylist))
 
-	request := &nextdns.CreateDenylistRequest{
-		ProfileID: profileID,
-		Denylist:  denylist,
+	if d.Get("authoritative").(bool) {
+		request := &nextdns.CreateDenylistRequest{
+			ProfileID: profileID,
+			Denylist:  denylist,
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+
+		err = client.Denylist.Create(ctx, request)
+	} else {
+		previousDomain, desiredDomain := d.GetChange("domain")
+		previousSource, desiredSource := d.GetChange("source_domain")
+		previousExpired, desiredExpired := d.GetChange("expired_domains")
+		err = syncDenylist(ctx, meta.(*providerMeta), profileID, declaredDomains(previousDomain, previousSource, previousExpired), declaredDomains(desiredDomain, desiredSource, desiredExpired))
 	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
-
-	err = client.Denylist.Create(ctx, request)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error updating deny list: %w", err))
+		return apiErrorDiags(err, "error updating deny list", setErrorTarget("", d, "domain", "id"))
 	}
 
-	return resourceNextDNSDenylistRead(ctx, d, meta)
+	return readDenylist(ctx, d, meta)
 }
 
 func resourceNextDNSDenylistDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
-	request := &nextdns.CreateDenylistRequest{
-		ProfileID: profileID,
-		Denylist:  []*nextdns.Denylist{},
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
+	var err error
+	if d.Get("authoritative").(bool) {
+		request := &nextdns.CreateDenylistRequest{
+			ProfileID: profileID,
+			Denylist:  []*nextdns.Denylist{},
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
 
-	err := client.Denylist.Create(ctx, request)
+		err = client.Denylist.Create(ctx, request)
+	} else {
+		err = syncDenylist(ctx, meta.(*providerMeta), profileID, declaredDomains(d.Get("domain"), d.Get("source_domain"), d.Get("expired_domains")), nil)
+	}
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error deleting deny list: %w", err))
+		if isNotFound(err) {
+			return nil
+		}
+		return apiErrorDiags(err, "error deleting deny list")
 	}
 
-	return resourceNextDNSDenylistRead(ctx, d, meta)
+	return readDenylist(ctx, d, meta)
 }
 
 func resourceNextDNSDenylistImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	profileID := d.Id()
-	d.SetId(profileID)
-	d.Set("profile_id", profileID)
+	return importDomainList(ctx, d, meta, resourceNextDNSDenylistRead)
+}
+
+// flattenDenylist returns the domain blocks of the entries.
+func flattenDenylist(denylist []*nextdns.Denylist) []map[string]interface{} {
+	var domains []map[string]interface{}
 
-	resourceNextDNSDenylistRead(ctx, d, meta)
+	for _, e := range denylist {
+		domain := make(map[string]interface{})
+		domain["id"] = e.ID
+		domain["active"] = e.Active
+
+		domains = append(domains, domain)
+	}
 
-	return []*schema.ResourceData{d}, nil
+	return domains
 }
 
-func buildDenylist(d *schema.ResourceData) ([]*nextdns.Denylist, error) {
+func buildDenylist(d resourceData) ([]*nextdns.Denylist, error) {
 	found, ok := d.GetOk("domain")
-	if !ok {
+	_, hasSource := d.GetOk("source")
+	if !ok && !hasSource {
 		// nolint:goerr113
 		return nil, errors.New("unable to find domain in resource data")
 	}
 
-	records := found.(*schema.Set).List()
+	var records []interface{}
+	if ok {
+		records = found.(*schema.Set).List()
+	}
 
-	denylist := make([]*nextdns.Denylist, len(records))
-	for k, v := range records {
-		denylist[k] = &nextdns.Denylist{
-			ID:     v.(map[string]interface{})["id"].(string),
+	denylist := make([]*nextdns.Denylist, 0, len(records))
+	// The expired domains are removed from the list.
+	expired := toSet(stringsFromSet(d.Get("expired_domains")))
+	for _, v := range records {
+		if expired[normalizeDomain(v.(map[string]interface{})["id"].(string))] {
+			continue
+		}
+		denylist = append(denylist, &nextdns.Denylist{
+			ID:     normalizeDomain(v.(map[string]interface{})["id"].(string)),
 			Active: v.(map[string]interface{})["active"].(bool),
+		})
+	}
+
+	// The domains loaded from the sources are sent after the declared ones, which take precedence.
+	declared := domainsFromSet(found)
+	sources := domainsFromSet(d.Get("source_domain"))
+	for _, id := range sortedKeys(sources) {
+		if _, ok := declared[id]; !ok {
+			denylist = append(denylist, &nextdns.Denylist{
+				ID:     id,
+				Active: sources[id],
+			})
 		}
 	}
 
 	return denylist, nil
 }
+
+// syncDenylist adds, updates and removes only the declared domains of the deny list,
+// leaving the domains added outside of Terraform untouched.
+func syncDenylist(ctx context.Context, m *providerMeta, profileID string, previous, desired map[string]bool) error {
+	request := &nextdns.ListDenylistRequest{
+		ProfileID: profileID,
+	}
+	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+
+	denylist, err := m.client.Denylist.List(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool, len(denylist))
+	for _, e := range denylist {
+		existing[e.ID] = e.Active
+	}
+
+	return syncDomainList(ctx, m.api, profileID, denylistAPIPath, existing, previous, desired)
+}
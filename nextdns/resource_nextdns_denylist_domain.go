@@ -0,0 +1,134 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/amalucelli/nextdns-go/nextdns"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// denylistAPIPath is the path of the denylist within a profile.
+const denylistAPIPath = "denylist"
+
+func resourceNextDNSDenylistDomain() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceNextDNSDenylistDomainSchema(),
+		CreateContext: resourceNextDNSDenylistDomainCreate,
+		ReadContext:   resourceNextDNSDenylistDomainRead,
+		UpdateContext: resourceNextDNSDenylistDomainUpdate,
+		DeleteContext: resourceNextDNSDenylistDomainDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceNextDNSDenylistDomainImport,
+		},
+	}
+}
+
+func resourceNextDNSDenylistDomainCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	api := meta.(*providerMeta).api
+	profileID := d.Get("profile_id").(string)
+	domain := normalizeDomain(d.Get("domain").(string))
+
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
+	entry := &nextdns.Denylist{
+		ID:     domain,
+		Active: d.Get("active").(bool),
+	}
+	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", entry))
+
+	err := api.addListEntry(ctx, profileID, denylistAPIPath, entry)
+	if err != nil {
+		return apiErrorDiags(err, "error creating deny list domain", fieldErrorTarget("/id", d, "domain"))
+	}
+
+	d.SetId(profileID + "/" + domain)
+
+	return resourceNextDNSDenylistDomainRead(ctx, d, meta)
+}
+
+func resourceNextDNSDenylistDomainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	profiles := meta.(*providerMeta).profiles
+	profileID := d.Get("profile_id").(string)
+	domain := normalizeDomain(d.Get("domain").(string))
+
+	profile, err := profiles.get(ctx, profileID)
+	if err != nil {
+		if isNotFound(err) {
+			return removeNotFound(ctx, d, "nextdns_denylist_domain")
+		}
+		return apiErrorDiags(err, "error getting deny list")
+	}
+	denylist := profile.Denylist
+	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", denylist))
+
+	var entry *nextdns.Denylist
+	for _, e := range denylist {
+		if e.ID == domain {
+			entry = e
+			break
+		}
+	}
+
+	if entry == nil {
+		return removeFromState(ctx, d,
+			"nextdns_denylist_domain not found",
+			fmt.Sprintf("The domain %q is no longer in the deny list of the profile %q, so it was removed from the state and will be recreated on the next apply.", domain, profileID),
+		)
+	}
+
+	d.Set("active", entry.Active)
+
+	return nil
+}
+
+func resourceNextDNSDenylistDomainUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	profileID := d.Get("profile_id").(string)
+	domain := normalizeDomain(d.Get("domain").(string))
+
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
+	request := &nextdns.UpdateDenylistRequest{
+		ProfileID: profileID,
+		ID:        domain,
+		Denylist: &nextdns.Denylist{
+			Active: d.Get("active").(bool),
+		},
+	}
+	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+
+	err := client.Denylist.Update(ctx, request)
+	if err != nil {
+		return apiErrorDiags(err, "error updating deny list domain")
+	}
+
+	return resourceNextDNSDenylistDomainRead(ctx, d, meta)
+}
+
+func resourceNextDNSDenylistDomainDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	api := meta.(*providerMeta).api
+	profileID := d.Get("profile_id").(string)
+	domain := normalizeDomain(d.Get("domain").(string))
+
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
+	err := api.deleteListEntry(ctx, profileID, denylistAPIPath, domain)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return apiErrorDiags(err, "error deleting deny list domain")
+	}
+
+	return nil
+}
+
+func resourceNextDNSDenylistDomainImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	return importListDomain(ctx, d, meta, resourceNextDNSDenylistDomainRead)
+}
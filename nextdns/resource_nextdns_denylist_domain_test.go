@@ -0,0 +1,94 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/amalucelli/terraform-provider-nextdns/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNextDNSDenylistDomain_basic(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNextDNSDenylistDomainConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_denylist_domain.test", "domain", "example.com"),
+					resource.TestCheckResourceAttr("nextdns_denylist_domain.test", "active", "true"),
+				),
+			},
+			{
+				Config: testAccNextDNSDenylistDomainConfig(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_denylist_domain.test", "active", "false"),
+					testAccCheckProfile(s, "nextdns_denylist_domain.test", func(profile map[string]interface{}) error {
+						denylist := profile["denylist"].([]interface{})
+						if len(denylist) != 1 || denylist[0].(map[string]interface{})["active"] != false {
+							return fmt.Errorf("unexpected deny list %v", denylist)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:      "nextdns_denylist_domain.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNextDNSDenylistDomainConfig(active bool) string {
+	return testAccProfileConfig() + fmt.Sprintf(`
+resource "nextdns_denylist_domain" "test" {
+  profile_id = nextdns_profile.test.id
+  domain     = "example.com"
+  active     = %t
+}
+`, active)
+}
+
+func TestResourceNextDNSDenylistDomainImport(t *testing.T) {
+	s := fakeapi.New()
+	defer s.Close()
+
+	meta, err := newProviderMeta(&clientConfig{apiKey: "test", apiURL: s.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profileID := s.CreateProfile(map[string]interface{}{
+		"name":     "test",
+		"denylist": []interface{}{map[string]interface{}{"id": "example.com", "active": true}},
+	})
+
+	tests := []struct {
+		id      string
+		wantErr bool
+	}{
+		{id: profileID + "/Example.COM", wantErr: false},
+		{id: profileID + "/example.org", wantErr: true},
+		{id: "unknown/example.com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		r := resourceNextDNSDenylistDomain()
+		d := r.Data(nil)
+		d.SetId(tt.id)
+
+		_, err := r.Importer.StateContext(context.Background(), d, meta)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("import of %s: unexpected error %v", tt.id, err)
+		}
+		if err == nil && (d.Id() != profileID+"/example.com" || !d.Get("active").(bool)) {
+			t.Errorf("import of %s: unexpected state %s %v", tt.id, d.Id(), d.Get("active"))
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/amalucelli/terraform-provider-nextdns/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccNextDNSDenylist_basic(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNextDNSDenylistConfig(true, "example.com", "example.org"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_denylist.test", "domain.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("nextdns_denylist.test", "domain.*", map[string]string{
+						"id":     "example.com",
+						"active": "true",
+					}),
+				),
+			},
+			{
+				Config: testAccNextDNSDenylistConfig(true, "example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_denylist.test", "domain.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "nextdns_denylist.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// An imported list is not authoritative until the configuration makes it so.
+				ImportStateVerifyIgnore: []string{"authoritative"},
+			},
+		},
+	})
+}
+
+func TestAccNextDNSDenylist_nonAuthoritative(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNextDNSDenylistConfig(false, "example.com"),
+			},
+			{
+				// A domain added outside of Terraform must be kept when the resource is not authoritative.
+				PreConfig: func() {
+					testAccAddListEntry(s, map[string]interface{}{"id": "other.com", "active": true}, "denylist")
+				},
+				Config: testAccNextDNSDenylistConfig(false, "example.org"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_denylist.test", "domain.#", "1"),
+					testAccCheckProfile(s, "nextdns_denylist.test", func(profile map[string]interface{}) error {
+						denylist := profile["denylist"].([]interface{})
+						if len(denylist) != 2 {
+							return fmt.Errorf("unexpected deny list %v", denylist)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNextDNSDenylist_source(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNextDNSDenylistSourceConfig("||tracker.com^"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_denylist.test", "domain.#", "1"),
+					resource.TestCheckResourceAttr("nextdns_denylist.test", "source_domain.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("nextdns_denylist.test", "source_domain.*", map[string]string{
+						"id":     "ads.example.com",
+						"active": "true",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("nextdns_denylist.test", "source_domain.*", map[string]string{
+						"id":     "tracker.com",
+						"active": "true",
+					}),
+				),
+			},
+			{
+				// The exceptions of an adblock list remove the domain from the deny list.
+				Config: testAccNextDNSDenylistSourceConfig("||tracker.com^", "@@||ads.example.com^"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_denylist.test", "source_domain.#", "1"),
+					testAccCheckProfile(s, "nextdns_denylist.test", func(profile map[string]interface{}) error {
+						denylist := profile["denylist"].([]interface{})
+						if len(denylist) != 2 {
+							return fmt.Errorf("unexpected deny list %v", denylist)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccNextDNSDenylistSourceConfig(rules ...string) string {
+	return testAccProfileConfig() + fmt.Sprintf(`
+resource "nextdns_denylist" "test" {
+  profile_id = nextdns_profile.test.id
+
+  domain {
+    id     = "example.com"
+    active = true
+  }
+
+  source {
+    format  = "hosts"
+    content = "# comment\n127.0.0.1 localhost\n0.0.0.0 ads.example.com example.com\n"
+  }
+
+  source {
+    format  = "adblock"
+    content = %q
+  }
+}
+`, strings.Join(rules, "\n"))
+}
+
+func TestAccNextDNSDenylist_expiry(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				// The expired domain is kept in the configuration, but removed from the deny list.
+				Config: testAccProfileConfig() + `
+resource "nextdns_denylist" "test" {
+  profile_id = nextdns_profile.test.id
+
+  domain {
+    id     = "example.com"
+    active = true
+  }
+
+  domain {
+    id         = "example.org"
+    active     = true
+    expires_at = "2020-01-01T00:00:00Z"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_denylist.test", "domain.#", "2"),
+					resource.TestCheckTypeSetElemAttr("nextdns_denylist.test", "expired_domains.*", "example.org"),
+					testAccCheckProfile(s, "nextdns_denylist.test", func(profile map[string]interface{}) error {
+						denylist := profile["denylist"].([]interface{})
+						if len(denylist) != 1 {
+							return fmt.Errorf("unexpected deny list %v", denylist)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccNextDNSDenylistConfig(authoritative bool, domains ...string) string {
+	config := testAccProfileConfig() + fmt.Sprintf(`
+resource "nextdns_denylist" "test" {
+  profile_id    = nextdns_profile.test.id
+  authoritative = %t
+`, authoritative)
+
+	for _, domain := range domains {
+		config += fmt.Sprintf(`
+  domain {
+    id     = %q
+    active = true
+  }
+`, domain)
+	}
+
+	return config + "}\n"
+}
+
+func TestResourceNextDNSDenylistImport(t *testing.T) {
+	s := fakeapi.New()
+	defer s.Close()
+
+	meta, err := newProviderMeta(&clientConfig{apiKey: "test", apiURL: s.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profileID := s.CreateProfile(map[string]interface{}{
+		"name": "test",
+		"denylist": []interface{}{
+			map[string]interface{}{"id": "example.com", "active": true},
+			map[string]interface{}{"id": "example.org", "active": false},
+		},
+	})
+
+	r := resourceNextDNSDenylist()
+	d := r.Data(nil)
+	d.SetId(profileID)
+
+	if _, err := r.Importer.StateContext(context.Background(), d, meta); err != nil {
+		t.Fatal(err)
+	}
+	if d.Get("authoritative").(bool) {
+		t.Error("expected the imported list not to be authoritative")
+	}
+
+	// The imported domains are kept by the following refreshes, though the list is not authoritative.
+	if diags := r.ReadContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if n := d.Get("domain").(*schema.Set).Len(); n != 2 {
+		t.Errorf("imported %d domains, want 2", n)
+	}
+
+	d = r.Data(nil)
+	d.SetId("unknown")
+	if _, err := r.Importer.StateContext(context.Background(), d, meta); err == nil {
+		t.Error("expected an error for an unknown profile")
+	}
+}
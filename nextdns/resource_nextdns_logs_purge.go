@@ -0,0 +1,62 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceNextDNSLogsPurge() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceNextDNSLogsPurgeSchema(),
+		CreateContext: resourceNextDNSLogsPurgeCreate,
+		ReadContext:   resourceNextDNSLogsPurgeRead,
+		DeleteContext: resourceNextDNSLogsPurgeDelete,
+	}
+}
+
+func resourceNextDNSLogsPurgeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	api := meta.(*providerMeta).api
+	profileID := d.Get("profile_id").(string)
+
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
+	tflog.Debug(ctx, fmt.Sprintf("purging logs of profile %s", profileID))
+
+	err := api.do(ctx, http.MethodDelete, profilePath(profileID, "logs"), nil, nil)
+	if err != nil {
+		return apiErrorDiags(err, "error purging logs")
+	}
+
+	d.SetId(profileID)
+	d.Set("purged_at", time.Now().UTC().Format(time.RFC3339))
+
+	return resourceNextDNSLogsPurgeRead(ctx, d, meta)
+}
+
+func resourceNextDNSLogsPurgeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	profiles := meta.(*providerMeta).profiles
+	profileID := d.Get("profile_id").(string)
+
+	// The purge is an action, there is nothing to read back besides the profile still existing.
+	_, err := profiles.get(ctx, profileID)
+	if err != nil {
+		if isNotFound(err) {
+			return removeNotFound(ctx, d, "nextdns_logs_purge")
+		}
+		return apiErrorDiags(err, "error getting profile")
+	}
+
+	return nil
+}
+
+func resourceNextDNSLogsPurgeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Purged logs cannot be restored, the resource is only removed from the state.
+	return nil
+}
@@ -0,0 +1,66 @@
+package nextdns
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccNextDNSLogsPurge_basic(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	seedLogs := func() {
+		for _, id := range s.ProfileIDs() {
+			s.SetLogs(id, []map[string]interface{}{
+				{"domain": "example.com", "status": "default"},
+			})
+		}
+	}
+
+	checkPurged := func(state *terraform.State) error {
+		for _, id := range s.ProfileIDs() {
+			if logs := s.Logs(id); len(logs) != 0 {
+				return fmt.Errorf("logs of profile %s were not purged: %v", id, logs)
+			}
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProfileConfig(),
+			},
+			{
+				PreConfig: seedLogs,
+				Config:    testAccNextDNSLogsPurgeConfig("us"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("nextdns_logs_purge.test", "purged_at"),
+					checkPurged,
+				),
+			},
+			{
+				// Changing the triggers purges the logs again.
+				PreConfig: seedLogs,
+				Config:    testAccNextDNSLogsPurgeConfig("eu"),
+				Check:     checkPurged,
+			},
+		},
+	})
+}
+
+func testAccNextDNSLogsPurgeConfig(location string) string {
+	return testAccProfileConfig() + fmt.Sprintf(`
+resource "nextdns_logs_purge" "test" {
+  profile_id = nextdns_profile.test.id
+
+  triggers = {
+    location = %[1]q
+  }
+}
+`, location)
+}
@@ -7,9 +7,14 @@ import (
 	"github.com/amalucelli/nextdns-go/nextdns"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// parentalControlSettings are the attributes written to the parental control settings,
+// the services and categories having their own endpoints.
+var parentalControlSettings = []string{"safe_search", "youtube_restricted_mode", "block_bypass", "recreation"}
+
 func resourceNextDNSParentalControl() *schema.Resource {
 	return &schema.Resource{
 		Schema:        resourceNextDNSParentalControlSchema(),
@@ -17,6 +22,10 @@ func resourceNextDNSParentalControl() *schema.Resource {
 		ReadContext:   resourceNextDNSParentalControlRead,
 		UpdateContext: resourceNextDNSParentalControlUpdate,
 		DeleteContext: resourceNextDNSParentalControlDelete,
+		CustomizeDiff: customdiff.Sequence(
+			customizeDiffRecreation("recreation"),
+			customizeDiffParentalControlCatalog(""),
+		),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceNextDNSParentalControlImport,
 		},
@@ -24,9 +33,12 @@ func resourceNextDNSParentalControl() *schema.Resource {
 }
 
 func resourceNextDNSParentalControlCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
 	parentalControl, err := buildParentalControl(d)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error creating parental control settings: %w", err))
@@ -41,7 +53,7 @@ func resourceNextDNSParentalControlCreate(ctx context.Context, d *schema.Resourc
 
 	err = client.ParentalControlServices.Create(ctx, services)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error creating services settings: %w", err))
+		return apiErrorDiags(err, "error creating services settings", setErrorTarget("", d, "service", "id"))
 	}
 
 	categories := &nextdns.CreateParentalControlCategoriesRequest{
@@ -52,7 +64,7 @@ func resourceNextDNSParentalControlCreate(ctx context.Context, d *schema.Resourc
 
 	err = client.ParentalControlCategories.Create(ctx, categories)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error creating categories settings: %w", err))
+		return apiErrorDiags(err, "error creating categories settings", setErrorTarget("", d, "category", "id"))
 	}
 
 	request := &nextdns.UpdateParentalControlRequest{
@@ -63,7 +75,10 @@ func resourceNextDNSParentalControlCreate(ctx context.Context, d *schema.Resourc
 
 	err = client.ParentalControl.Update(ctx, request)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error creating parental control settings: %w", err))
+		return apiErrorDiags(err, "error creating parental control settings",
+			setErrorTarget("/services", d, "service", "id"),
+			setErrorTarget("/categories", d, "category", "id"),
+		)
 	}
 
 	d.SetId(profileID)
@@ -72,113 +87,25 @@ func resourceNextDNSParentalControlCreate(ctx context.Context, d *schema.Resourc
 }
 
 func resourceNextDNSParentalControlRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	profiles := meta.(*providerMeta).profiles
 	profileID := d.Get("profile_id").(string)
 
-	request := &nextdns.GetParentalControlRequest{
-		ProfileID: profileID,
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
-
-	parentalControl, err := client.ParentalControl.Get(ctx, request)
+	profile, err := profiles.get(ctx, profileID)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error getting parental control settings: %w", err))
-	}
-	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", parentalControl))
-
-	if parentalControl.Recreation != nil {
-		recreation := map[string]interface{}{}
-		recreation["timezone"] = parentalControl.Recreation.Timezone
-
-		if parentalControl.Recreation.Times.Monday != nil {
-			recreation["monday"] = []map[string]interface{}{
-				{
-					"start": parentalControl.Recreation.Times.Monday.Start,
-					"end":   parentalControl.Recreation.Times.Monday.End,
-				},
-			}
-		}
-		if parentalControl.Recreation.Times.Tuesday != nil {
-			recreation["tuesday"] = []map[string]interface{}{
-				{
-					"start": parentalControl.Recreation.Times.Tuesday.Start,
-					"end":   parentalControl.Recreation.Times.Tuesday.End,
-				},
-			}
-		}
-		if parentalControl.Recreation.Times.Wednesday != nil {
-			recreation["wednesday"] = []map[string]interface{}{
-				{
-					"start": parentalControl.Recreation.Times.Wednesday.Start,
-					"end":   parentalControl.Recreation.Times.Wednesday.End,
-				},
-			}
-		}
-		if parentalControl.Recreation.Times.Thursday != nil {
-			recreation["thursday"] = []map[string]interface{}{
-				{
-					"start": parentalControl.Recreation.Times.Thursday.Start,
-					"end":   parentalControl.Recreation.Times.Thursday.End,
-				},
-			}
+		if isNotFound(err) {
+			return removeNotFound(ctx, d, "nextdns_parental_control")
 		}
-		if parentalControl.Recreation.Times.Friday != nil {
-			recreation["friday"] = []map[string]interface{}{
-				{
-					"start": parentalControl.Recreation.Times.Friday.Start,
-					"end":   parentalControl.Recreation.Times.Friday.End,
-				},
-			}
-		}
-		if parentalControl.Recreation.Times.Saturday != nil {
-			recreation["saturday"] = []map[string]interface{}{
-				{
-					"start": parentalControl.Recreation.Times.Saturday.Start,
-					"end":   parentalControl.Recreation.Times.Saturday.End,
-				},
-			}
-		}
-		if parentalControl.Recreation.Times.Sunday != nil {
-			recreation["sunday"] = []map[string]interface{}{
-				{
-					"start": parentalControl.Recreation.Times.Sunday.Start,
-					"end":   parentalControl.Recreation.Times.Sunday.End,
-				},
-			}
-		}
-
-		d.Set("recreation", []map[string]interface{}{recreation})
+		return apiErrorDiags(err, "error getting parental control settings")
 	}
-
-	var services []map[string]interface{}
-	for _, s := range parentalControl.Services {
-		service := make(map[string]interface{})
-		service["id"] = s.ID
-		service["active"] = s.Active
-		service["recreation"] = s.Recreation
-
-		services = append(services, service)
+	parentalControl := profile.ParentalControl
+	if parentalControl == nil {
+		return diag.Errorf("error getting parental control settings: the profile %q has no parental control settings", profileID)
 	}
-	if err := d.Set("service", services); err != nil {
-		return diag.FromErr(err)
-	}
-
-	var categories []map[string]interface{}
-	for _, c := range parentalControl.Categories {
-		category := make(map[string]interface{})
-		category["id"] = c.ID
-		category["active"] = c.Active
-		category["recreation"] = c.Recreation
+	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", parentalControl))
 
-		categories = append(categories, category)
+	if diags := setFlattened(d, flattenParentalControl(parentalControl, d.Get("recreation"))); diags.HasError() {
+		return diags
 	}
-	if err := d.Set("category", categories); err != nil {
-		return diag.FromErr(err)
-	}
-
-	d.Set("block_bypass", parentalControl.BlockBypass)
-	d.Set("safe_search", parentalControl.SafeSearch)
-	d.Set("youtube_restricted_mode", parentalControl.YoutubeRestrictedMode)
 
 	d.SetId(profileID)
 
@@ -186,55 +113,76 @@ func resourceNextDNSParentalControlRead(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceNextDNSParentalControlUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
 	parentalControl, err := buildParentalControl(d)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error updating parental control settings: %w", err))
 	}
 	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", parentalControl))
 
-	services := &nextdns.CreateParentalControlServicesRequest{
-		ProfileID:               profileID,
-		ParentalControlServices: parentalControl.Services,
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", services))
+	// The services, categories and settings are written by separate requests. When one fails, the sections which
+	// were not written are reverted, so the state keeps matching the profile.
+	if d.HasChange("service") {
+		services := &nextdns.CreateParentalControlServicesRequest{
+			ProfileID:               profileID,
+			ParentalControlServices: parentalControl.Services,
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", services))
 
-	err = client.ParentalControlServices.Create(ctx, services)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error updating services settings: %w", err))
+		err = client.ParentalControlServices.Create(ctx, services)
+		if err != nil {
+			revertChanges(d, append([]string{"service", "category"}, parentalControlSettings...)...)
+			return apiErrorDiags(err, "error updating services settings", setErrorTarget("", d, "service", "id"))
+		}
 	}
 
-	categories := &nextdns.CreateParentalControlCategoriesRequest{
-		ProfileID:                 profileID,
-		ParentalControlCategories: parentalControl.Categories,
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", categories))
+	if d.HasChange("category") {
+		categories := &nextdns.CreateParentalControlCategoriesRequest{
+			ProfileID:                 profileID,
+			ParentalControlCategories: parentalControl.Categories,
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", categories))
 
-	err = client.ParentalControlCategories.Create(ctx, categories)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error updating categories settings: %w", err))
+		err = client.ParentalControlCategories.Create(ctx, categories)
+		if err != nil {
+			revertChanges(d, append([]string{"category"}, parentalControlSettings...)...)
+			return apiErrorDiags(err, "error updating categories settings", setErrorTarget("", d, "category", "id"))
+		}
 	}
 
-	request := &nextdns.UpdateParentalControlRequest{
-		ProfileID:       profileID,
-		ParentalControl: parentalControl,
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+	if d.HasChanges(parentalControlSettings...) {
+		// The services and categories are written through their own endpoints.
+		parentalControl.Services = nil
+		parentalControl.Categories = nil
 
-	err = client.ParentalControl.Update(ctx, request)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error updating parental control settings: %w", err))
+		request := &nextdns.UpdateParentalControlRequest{
+			ProfileID:       profileID,
+			ParentalControl: parentalControl,
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+
+		err = client.ParentalControl.Update(ctx, request)
+		if err != nil {
+			revertChanges(d, parentalControlSettings...)
+			return apiErrorDiags(err, "error updating parental control settings")
+		}
 	}
 
 	return resourceNextDNSParentalControlRead(ctx, d, meta)
 }
 
 func resourceNextDNSParentalControlDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
 	services := &nextdns.CreateParentalControlServicesRequest{
 		ProfileID:               profileID,
 		ParentalControlServices: []*nextdns.ParentalControlServices{},
@@ -243,7 +191,10 @@ func resourceNextDNSParentalControlDelete(ctx context.Context, d *schema.Resourc
 
 	err := client.ParentalControlServices.Create(ctx, services)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error deleting services settings: %w", err))
+		if isNotFound(err) {
+			return nil
+		}
+		return apiErrorDiags(err, "error deleting services settings")
 	}
 
 	categories := &nextdns.CreateParentalControlCategoriesRequest{
@@ -254,7 +205,7 @@ func resourceNextDNSParentalControlDelete(ctx context.Context, d *schema.Resourc
 
 	err = client.ParentalControlCategories.Create(ctx, categories)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error deleting categories settings: %w", err))
+		return apiErrorDiags(err, "error deleting categories settings")
 	}
 
 	parentalControl := &nextdns.UpdateParentalControlRequest{
@@ -265,7 +216,7 @@ func resourceNextDNSParentalControlDelete(ctx context.Context, d *schema.Resourc
 
 	err = client.ParentalControl.Update(ctx, parentalControl)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error deleting parental control settings: %w", err))
+		return apiErrorDiags(err, "error deleting parental control settings")
 	}
 
 	return resourceNextDNSParentalControlRead(ctx, d, meta)
@@ -281,73 +232,18 @@ func resourceNextDNSParentalControlImport(ctx context.Context, d *schema.Resourc
 	return []*schema.ResourceData{d}, nil
 }
 
-func buildParentalControl(d *schema.ResourceData) (*nextdns.ParentalControl, error) {
+func buildParentalControl(d resourceData) (*nextdns.ParentalControl, error) {
 	ParentalControl := &nextdns.ParentalControl{
 		BlockBypass:           d.Get("block_bypass").(bool),
 		SafeSearch:            d.Get("safe_search").(bool),
 		YoutubeRestrictedMode: d.Get("youtube_restricted_mode").(bool),
 	}
 
-	ParentalControl.Recreation = &nextdns.ParentalControlRecreation{}
-	if _, ok := d.GetOk("recreation"); ok {
-		times := &nextdns.ParentalControlRecreationTimes{}
-
-		if _, ok := d.GetOk("recreation.0.monday"); ok {
-			times.Monday = &nextdns.ParentalControlRecreationInterval{
-				Start: d.Get("recreation.0.monday.0.start").(string),
-				End:   d.Get("recreation.0.monday.0.end").(string),
-			}
-		}
-
-		if _, ok := d.GetOk("recreation.0.tuesday"); ok {
-			times.Tuesday = &nextdns.ParentalControlRecreationInterval{
-				Start: d.Get("recreation.0.tuesday.0.start").(string),
-				End:   d.Get("recreation.0.tuesday.0.end").(string),
-			}
-		}
-
-		if _, ok := d.GetOk("recreation.0.wednesday"); ok {
-			times.Wednesday = &nextdns.ParentalControlRecreationInterval{
-				Start: d.Get("recreation.0.wednesday.0.start").(string),
-				End:   d.Get("recreation.0.wednesday.0.end").(string),
-			}
-		}
-
-		if _, ok := d.GetOk("recreation.0.thursday"); ok {
-			times.Thursday = &nextdns.ParentalControlRecreationInterval{
-				Start: d.Get("recreation.0.thursday.0.start").(string),
-				End:   d.Get("recreation.0.thursday.0.end").(string),
-			}
-		}
-
-		if _, ok := d.GetOk("recreation.0.friday"); ok {
-			times.Friday = &nextdns.ParentalControlRecreationInterval{
-				Start: d.Get("recreation.0.friday.0.start").(string),
-				End:   d.Get("recreation.0.friday.0.end").(string),
-			}
-		}
-
-		if _, ok := d.GetOk("recreation.0.saturday"); ok {
-			times.Saturday = &nextdns.ParentalControlRecreationInterval{
-				Start: d.Get("recreation.0.saturday.0.start").(string),
-				End:   d.Get("recreation.0.saturday.0.end").(string),
-			}
-		}
-
-		if _, ok := d.GetOk("recreation.0.sunday"); ok {
-			times.Sunday = &nextdns.ParentalControlRecreationInterval{
-				Start: d.Get("recreation.0.sunday.0.start").(string),
-				End:   d.Get("recreation.0.sunday.0.end").(string),
-			}
-		}
-
-		recreation := &nextdns.ParentalControlRecreation{
-			Times:    times,
-			Timezone: d.Get("recreation.0.timezone").(string),
-		}
-
-		ParentalControl.Recreation = recreation
+	recreation, err := buildRecreation(d.Get("recreation"))
+	if err != nil {
+		return nil, err
 	}
+	ParentalControl.Recreation = recreation
 
 	ParentalControl.Services = []*nextdns.ParentalControlServices{}
 	if foundSvc, ok := d.GetOk("service"); ok {
@@ -381,3 +277,41 @@ func buildParentalControl(d *schema.ResourceData) (*nextdns.ParentalControl, err
 
 	return ParentalControl, nil
 }
+
+// flattenParentalControl returns the attributes of the parental control settings,
+// given the current recreation block which decides how the recreation windows are described.
+func flattenParentalControl(parentalControl *nextdns.ParentalControl, recreation interface{}) map[string]interface{} {
+	values := make(map[string]interface{})
+
+	if parentalControl.Recreation != nil {
+		values["recreation"] = []map[string]interface{}{flattenRecreation(parentalControl.Recreation, recreation)}
+	}
+
+	var services []map[string]interface{}
+	for _, s := range parentalControl.Services {
+		service := make(map[string]interface{})
+		service["id"] = s.ID
+		service["active"] = s.Active
+		service["recreation"] = s.Recreation
+
+		services = append(services, service)
+	}
+	values["service"] = services
+
+	var categories []map[string]interface{}
+	for _, c := range parentalControl.Categories {
+		category := make(map[string]interface{})
+		category["id"] = c.ID
+		category["active"] = c.Active
+		category["recreation"] = c.Recreation
+
+		categories = append(categories, category)
+	}
+	values["category"] = categories
+
+	values["block_bypass"] = parentalControl.BlockBypass
+	values["safe_search"] = parentalControl.SafeSearch
+	values["youtube_restricted_mode"] = parentalControl.YoutubeRestrictedMode
+
+	return values
+}
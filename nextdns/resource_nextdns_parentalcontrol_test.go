@@ -0,0 +1,298 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/amalucelli/terraform-provider-nextdns/internal/fakeapi"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccNextDNSParentalControl_basic(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNextDNSParentalControlConfig(true, "tiktok"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_parental_control.test", "safe_search", "true"),
+					resource.TestCheckResourceAttr("nextdns_parental_control.test", "service.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("nextdns_parental_control.test", "service.*", map[string]string{
+						"id":     "tiktok",
+						"active": "true",
+					}),
+					resource.TestCheckResourceAttr("nextdns_parental_control.test", "category.#", "1"),
+					resource.TestCheckResourceAttr("nextdns_parental_control.test", "recreation.0.timezone", "Europe/Paris"),
+				),
+			},
+			{
+				Config: testAccNextDNSParentalControlConfig(false, "fortnite"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_parental_control.test", "safe_search", "false"),
+					resource.TestCheckTypeSetElemNestedAttrs("nextdns_parental_control.test", "service.*", map[string]string{
+						"id": "fortnite",
+					}),
+					testAccCheckProfile(s, "nextdns_parental_control.test", func(profile map[string]interface{}) error {
+						services := profile["parentalControl"].(map[string]interface{})["services"].([]interface{})
+						if len(services) != 1 || services[0].(map[string]interface{})["id"] != "fortnite" {
+							return fmt.Errorf("unexpected services %v", services)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:      "nextdns_parental_control.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNextDNSParentalControl_partialUpdate(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	var mark int
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNextDNSParentalControlConfig(true, "tiktok"),
+			},
+			{
+				PreConfig: testAccMarkRequests(s, &mark),
+				Config:    testAccNextDNSParentalControlConfig(false, "tiktok"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_parental_control.test", "safe_search", "false"),
+					testAccCheckWrites(s, "nextdns_parental_control.test", &mark, "PATCH parentalControl"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceNextDNSParentalControlUpdate_partialFailure(t *testing.T) {
+	s := fakeapi.New()
+	defer s.Close()
+
+	meta, err := newProviderMeta(&clientConfig{apiKey: "test", apiURL: s.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	profileID := s.CreateProfile(map[string]interface{}{"name": "test"})
+
+	r := resourceNextDNSParentalControl()
+	block := schema.InternalMap(r.Schema).CoreConfigSchema()
+	apply := func(state *terraform.InstanceState, service, category string, safeSearch bool) (*terraform.InstanceState, error) {
+		config, err := ctyjson.Unmarshal([]byte(fmt.Sprintf(`{"profile_id": %q, "allow_unknown_ids": true,
+			"safe_search": %t, "youtube_restricted_mode": false, "block_bypass": true,
+			"service": [{"id": %q, "active": true, "recreation": false}],
+			"category": [{"id": %q, "active": true, "recreation": false}]}`, profileID, safeSearch, service, category)), block.ImpliedType())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigShimmed(config, block), meta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		diff.RawConfig = config
+
+		state, diags := r.Apply(context.Background(), state, diff, meta)
+		if diags.HasError() {
+			return state, fmt.Errorf("%v", diags)
+		}
+		return state, nil
+	}
+
+	state, err := apply(nil, "tiktok", "gambling", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The services are written, but the categories are rejected and the settings are not written.
+	s.Reject("dating")
+	state, err = apply(state, "instagram", "dating", false)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	want := map[string]string{
+		"service.#":   "1",
+		"category.#":  "1",
+		"safe_search": "true",
+	}
+	for k, v := range want {
+		if state.Attributes[k] != v {
+			t.Errorf("%s = %q, want %q", k, state.Attributes[k], v)
+		}
+	}
+	d := r.Data(state)
+	if id := d.Get("category").(*schema.Set).List()[0].(map[string]interface{})["id"]; id != "gambling" {
+		t.Errorf("category = %v, want the prior gambling", id)
+	}
+	if id := d.Get("service").(*schema.Set).List()[0].(map[string]interface{})["id"]; id != "instagram" {
+		t.Errorf("service = %v, want the written instagram", id)
+	}
+}
+
+func TestAccNextDNSParentalControl_schedule(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProfileConfig() + `
+resource "nextdns_parental_control" "test" {
+  profile_id = nextdns_profile.test.id
+
+  safe_search             = true
+  youtube_restricted_mode = false
+  block_bypass            = true
+
+  recreation {
+    timezone = "America/New_York"
+
+    schedule {
+      days  = ["monday", "tuesday", "wednesday", "thursday"]
+      start = "16:00:00"
+      end   = "18:00:00"
+    }
+
+    schedule {
+      days             = ["friday", "saturday"]
+      start            = "20:00:00"
+      end              = "01:00:00"
+      crosses_midnight = true
+    }
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_parental_control.test", "recreation.0.schedule.#", "2"),
+					testAccCheckProfile(s, "nextdns_parental_control.test", func(profile map[string]interface{}) error {
+						times := profile["parentalControl"].(map[string]interface{})["recreation"].(map[string]interface{})["times"].(map[string]interface{})
+						if len(times) != 6 || times["saturday"].(map[string]interface{})["end"] != "01:00:00" {
+							return fmt.Errorf("unexpected recreation times %v", times)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config: testAccProfileConfig() + `
+resource "nextdns_parental_control" "test" {
+  profile_id = nextdns_profile.test.id
+
+  safe_search             = true
+  youtube_restricted_mode = false
+  block_bypass            = true
+
+  recreation {
+    timezone = "America/New_York"
+
+    schedule {
+      days  = ["monday"]
+      start = "18:00:00"
+      end   = "16:00:00"
+    }
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`must precede the end`),
+			},
+		},
+	})
+}
+
+func TestAccNextDNSParentalControl_catalog(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNextDNSParentalControlCatalogConfig("allow_unknown_ids = false", "tiktock"),
+				ExpectError: regexp.MustCompile(`unknown service "tiktock", did you mean "tiktok"\?`),
+			},
+			{
+				Config:      testAccNextDNSParentalControlCatalogConfig("", "tiktock"),
+				ExpectError: regexp.MustCompile(`unknown service "tiktock", did you mean "tiktok"\?`),
+			},
+			{
+				Config: testAccNextDNSParentalControlCatalogConfig("allow_unknown_ids = true", "brand-new-service"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_parental_control.test", "allow_unknown_ids", "true"),
+					resource.TestCheckTypeSetElemNestedAttrs("nextdns_parental_control.test", "service.*", map[string]string{
+						"id": "brand-new-service",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccNextDNSParentalControlCatalogConfig(allowUnknownIDs, service string) string {
+	return testAccProfileConfig() + fmt.Sprintf(`
+resource "nextdns_parental_control" "test" {
+  profile_id = nextdns_profile.test.id
+  %[1]s
+
+  safe_search             = true
+  youtube_restricted_mode = false
+  block_bypass            = true
+
+  service {
+    id         = %[2]q
+    active     = true
+    recreation = false
+  }
+}
+`, allowUnknownIDs, service)
+}
+
+func testAccNextDNSParentalControlConfig(safeSearch bool, service string) string {
+	return testAccProfileConfig() + fmt.Sprintf(`
+resource "nextdns_parental_control" "test" {
+  profile_id = nextdns_profile.test.id
+
+  safe_search             = %[1]t
+  youtube_restricted_mode = false
+  block_bypass            = true
+
+  service {
+    id         = %[2]q
+    active     = true
+    recreation = false
+  }
+
+  category {
+    id         = "gambling"
+    active     = true
+    recreation = false
+  }
+
+  recreation {
+    timezone = "Europe/Paris"
+
+    monday {
+      start = "18:00:00"
+      end   = "20:00:00"
+    }
+  }
+}
+`, safeSearch, service)
+}
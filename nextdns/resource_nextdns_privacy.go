@@ -10,6 +10,16 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+const (
+	// privacyBlocklistsAPIPath is the path of the privacy blocklists within a profile.
+	privacyBlocklistsAPIPath = "privacy/blocklists"
+	// privacyNativesAPIPath is the path of the native tracking protections within a profile.
+	privacyNativesAPIPath = "privacy/natives"
+)
+
+// privacySettings are the attributes written to the privacy settings, the lists having their own endpoints.
+var privacySettings = []string{"disguised_trackers", "allow_affiliate"}
+
 func resourceNextDNSPrivacy() *schema.Resource {
 	return &schema.Resource{
 		Schema:        resourceNextDNSPrivacySchema(),
@@ -24,35 +34,52 @@ func resourceNextDNSPrivacy() *schema.Resource {
 }
 
 func resourceNextDNSPrivacyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
 	privacy, err := buildPrivacy(d)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error creating privacy settings: %w", err))
 	}
 	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", privacy))
 
-	blocklist := &nextdns.CreatePrivacyBlocklistsRequest{
-		ProfileID:         profileID,
-		PrivacyBlocklists: privacy.Blocklists,
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", blocklist))
+	authoritative := d.Get("authoritative").(bool)
 
-	err = client.PrivacyBlocklists.Create(ctx, blocklist)
+	if authoritative {
+		blocklist := &nextdns.CreatePrivacyBlocklistsRequest{
+			ProfileID:         profileID,
+			PrivacyBlocklists: privacy.Blocklists,
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", blocklist))
+
+		err = client.PrivacyBlocklists.Create(ctx, blocklist)
+	} else {
+		err = syncPrivacyBlocklists(ctx, meta.(*providerMeta), profileID, nil, d.Get("blocklists"))
+	}
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error creating blocklist settings: %w", err))
+		return apiErrorDiags(err, "error creating blocklist settings", listErrorTarget("", d, "blocklists"))
 	}
 
-	natives := &nextdns.CreatePrivacyNativesRequest{
-		ProfileID:      profileID,
-		PrivacyNatives: privacy.Natives,
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", natives))
+	if authoritative {
+		natives := &nextdns.CreatePrivacyNativesRequest{
+			ProfileID:      profileID,
+			PrivacyNatives: privacy.Natives,
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", natives))
+
+		err = client.PrivacyNatives.Create(ctx, natives)
+	} else {
+		err = syncPrivacyNatives(ctx, meta.(*providerMeta), profileID, nil, d.Get("natives"))
 
-	err = client.PrivacyNatives.Create(ctx, natives)
+		// The lists must not be sent with the settings, otherwise they would replace the whole lists.
+		privacy.Blocklists = nil
+		privacy.Natives = nil
+	}
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error creating native settings: %w", err))
+		return apiErrorDiags(err, "error creating native settings", listErrorTarget("", d, "natives"))
 	}
 
 	request := &nextdns.UpdatePrivacyRequest{
@@ -63,7 +90,10 @@ func resourceNextDNSPrivacyCreate(ctx context.Context, d *schema.ResourceData, m
 
 	err = client.Privacy.Update(ctx, request)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error creating privacy settings: %w", err))
+		return apiErrorDiags(err, "error creating privacy settings",
+			listErrorTarget("/blocklists", d, "blocklists"),
+			listErrorTarget("/natives", d, "natives"),
+		)
 	}
 	d.SetId(profileID)
 
@@ -71,97 +101,147 @@ func resourceNextDNSPrivacyCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceNextDNSPrivacyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	profiles := meta.(*providerMeta).profiles
 	profileID := d.Get("profile_id").(string)
 
-	request := &nextdns.GetPrivacyRequest{
-		ProfileID: profileID,
-	}
-	privacy, err := client.Privacy.Get(ctx, request)
+	profile, err := profiles.get(ctx, profileID)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error getting privacy settings: %w", err))
+		if isNotFound(err) {
+			return removeNotFound(ctx, d, "nextdns_privacy")
+		}
+		return apiErrorDiags(err, "error getting privacy settings")
+	}
+	privacy := profile.Privacy
+	if privacy == nil {
+		return diag.Errorf("error getting privacy settings: the profile %q has no privacy settings", profileID)
 	}
 
 	d.SetId(profileID)
 
-	d.Set("allow_affiliate", privacy.AllowAffiliate)
-	d.Set("disguised_trackers", privacy.DisguisedTrackers)
-	d.Set("blocklists", flattenBlocklists(privacy.Blocklists))
-	d.Set("natives", flattenNatives(privacy.Natives))
+	values := flattenPrivacy(privacy)
+
+	// When the resource is not authoritative, the entries added outside of Terraform are ignored.
+	if !d.Get("authoritative").(bool) {
+		values["blocklists"] = filterDeclared(values["blocklists"].([]string), stringsFromList(d.Get("blocklists")))
+		values["natives"] = filterDeclared(values["natives"].([]string), stringsFromList(d.Get("natives")))
+	}
+
+	if diags := setFlattened(d, values); diags.HasError() {
+		return diags
+	}
 
 	return nil
 }
 
 func resourceNextDNSPrivacyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
 	privacy, err := buildPrivacy(d)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error updating privacy settings: %w", err))
 	}
 	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", privacy))
 
-	blocklist := &nextdns.CreatePrivacyBlocklistsRequest{
-		ProfileID:         profileID,
-		PrivacyBlocklists: privacy.Blocklists,
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", blocklist))
+	authoritative := d.Get("authoritative").(bool)
 
-	err = client.PrivacyBlocklists.Create(ctx, blocklist)
+	// Switching to authoritative replaces the whole lists, even if the declared entries did not change.
+	if authoritative && d.HasChanges("blocklists", "authoritative") {
+		blocklist := &nextdns.CreatePrivacyBlocklistsRequest{
+			ProfileID:         profileID,
+			PrivacyBlocklists: privacy.Blocklists,
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", blocklist))
+
+		err = client.PrivacyBlocklists.Create(ctx, blocklist)
+	} else if !authoritative && d.HasChange("blocklists") {
+		previous, desired := d.GetChange("blocklists")
+		err = syncPrivacyBlocklists(ctx, meta.(*providerMeta), profileID, previous, desired)
+	}
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error deleting blocklist settings: %w", err))
+		return apiErrorDiags(err, "error updating blocklist settings", listErrorTarget("", d, "blocklists"))
 	}
 
-	natives := &nextdns.CreatePrivacyNativesRequest{
-		ProfileID:      profileID,
-		PrivacyNatives: privacy.Natives,
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", natives))
+	if authoritative && d.HasChanges("natives", "authoritative") {
+		natives := &nextdns.CreatePrivacyNativesRequest{
+			ProfileID:      profileID,
+			PrivacyNatives: privacy.Natives,
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", natives))
 
-	err = client.PrivacyNatives.Create(ctx, natives)
+		err = client.PrivacyNatives.Create(ctx, natives)
+	} else if !authoritative && d.HasChange("natives") {
+		previous, desired := d.GetChange("natives")
+		err = syncPrivacyNatives(ctx, meta.(*providerMeta), profileID, previous, desired)
+	}
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error deleting native settings: %w", err))
+		return apiErrorDiags(err, "error updating native settings", listErrorTarget("", d, "natives"))
 	}
 
-	request := &nextdns.UpdatePrivacyRequest{
-		ProfileID: profileID,
-		Privacy:   privacy,
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+	if d.HasChanges(privacySettings...) {
+		// The lists are written through their own endpoints.
+		privacy.Blocklists = nil
+		privacy.Natives = nil
 
-	err = client.Privacy.Update(ctx, request)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error deleting privacy settings: %w", err))
+		request := &nextdns.UpdatePrivacyRequest{
+			ProfileID: profileID,
+			Privacy:   privacy,
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+
+		err = client.Privacy.Update(ctx, request)
+		if err != nil {
+			return apiErrorDiags(err, "error updating privacy settings")
+		}
 	}
 
 	return resourceNextDNSPrivacyRead(ctx, d, meta)
 }
 
 func resourceNextDNSPrivacyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
-	blocklist := &nextdns.CreatePrivacyBlocklistsRequest{
-		ProfileID:         profileID,
-		PrivacyBlocklists: []*nextdns.PrivacyBlocklists{},
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", blocklist))
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
 
-	err := client.PrivacyBlocklists.Create(ctx, blocklist)
+	authoritative := d.Get("authoritative").(bool)
+
+	var err error
+	if authoritative {
+		blocklist := &nextdns.CreatePrivacyBlocklistsRequest{
+			ProfileID:         profileID,
+			PrivacyBlocklists: []*nextdns.PrivacyBlocklists{},
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", blocklist))
+
+		err = client.PrivacyBlocklists.Create(ctx, blocklist)
+	} else {
+		err = syncPrivacyBlocklists(ctx, meta.(*providerMeta), profileID, d.Get("blocklists"), nil)
+	}
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error deleting blocklist settings: %w", err))
+		if isNotFound(err) {
+			return nil
+		}
+		return apiErrorDiags(err, "error deleting blocklist settings")
 	}
 
-	natives := &nextdns.CreatePrivacyNativesRequest{
-		ProfileID:      profileID,
-		PrivacyNatives: []*nextdns.PrivacyNatives{},
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", natives))
+	if authoritative {
+		natives := &nextdns.CreatePrivacyNativesRequest{
+			ProfileID:      profileID,
+			PrivacyNatives: []*nextdns.PrivacyNatives{},
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", natives))
 
-	err = client.PrivacyNatives.Create(ctx, natives)
+		err = client.PrivacyNatives.Create(ctx, natives)
+	} else {
+		err = syncPrivacyNatives(ctx, meta.(*providerMeta), profileID, d.Get("natives"), nil)
+	}
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error deleting native settings: %w", err))
+		return apiErrorDiags(err, "error deleting native settings")
 	}
 
 	privacy := &nextdns.UpdatePrivacyRequest{
@@ -172,7 +252,7 @@ func resourceNextDNSPrivacyDelete(ctx context.Context, d *schema.ResourceData, m
 
 	err = client.Privacy.Update(ctx, privacy)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error deleting privacy settings: %w", err))
+		return apiErrorDiags(err, "error deleting privacy settings")
 	}
 
 	return resourceNextDNSPrivacyRead(ctx, d, meta)
@@ -182,12 +262,23 @@ func resourceNextDNSPrivacyImport(ctx context.Context, d *schema.ResourceData, m
 	profileID := d.Id()
 	d.SetId(profileID)
 	d.Set("profile_id", profileID)
+	d.Set("authoritative", true)
 
 	resourceNextDNSPrivacyRead(ctx, d, meta)
 
 	return []*schema.ResourceData{d}, nil
 }
 
+// flattenPrivacy returns the attributes of the privacy settings.
+func flattenPrivacy(privacy *nextdns.Privacy) map[string]interface{} {
+	return map[string]interface{}{
+		"allow_affiliate":    privacy.AllowAffiliate,
+		"disguised_trackers": privacy.DisguisedTrackers,
+		"blocklists":         flattenBlocklists(privacy.Blocklists),
+		"natives":            flattenNatives(privacy.Natives),
+	}
+}
+
 func flattenBlocklists(blocklists []*nextdns.PrivacyBlocklists) []string {
 	ids := make([]string, 0)
 	for _, entry := range blocklists {
@@ -206,7 +297,7 @@ func flattenNatives(natives []*nextdns.PrivacyNatives) []string {
 	return ids
 }
 
-func buildPrivacy(d *schema.ResourceData) (*nextdns.Privacy, error) {
+func buildPrivacy(d resourceData) (*nextdns.Privacy, error) {
 	privacy := &nextdns.Privacy{
 		AllowAffiliate:    d.Get("allow_affiliate").(bool),
 		DisguisedTrackers: d.Get("disguised_trackers").(bool),
@@ -240,3 +331,33 @@ func buildPrivacy(d *schema.ResourceData) (*nextdns.Privacy, error) {
 
 	return privacy, nil
 }
+
+// syncPrivacyBlocklists adds and removes only the declared blocklists, leaving the blocklists added outside of Terraform untouched.
+func syncPrivacyBlocklists(ctx context.Context, m *providerMeta, profileID string, previous, desired interface{}) error {
+	request := &nextdns.ListPrivacyBlocklistsRequest{
+		ProfileID: profileID,
+	}
+	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+
+	blocklists, err := m.client.PrivacyBlocklists.List(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	return syncIDList(ctx, m.api, profileID, privacyBlocklistsAPIPath, flattenBlocklists(blocklists), stringsFromList(previous), stringsFromList(desired))
+}
+
+// syncPrivacyNatives adds and removes only the declared native tracking protections, leaving the ones added outside of Terraform untouched.
+func syncPrivacyNatives(ctx context.Context, m *providerMeta, profileID string, previous, desired interface{}) error {
+	request := &nextdns.ListPrivacyNativesRequest{
+		ProfileID: profileID,
+	}
+	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+
+	natives, err := m.client.PrivacyNatives.List(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	return syncIDList(ctx, m.api, profileID, privacyNativesAPIPath, flattenNatives(natives), stringsFromList(previous), stringsFromList(desired))
+}
@@ -0,0 +1,59 @@
+package nextdns
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNextDNSPrivacy_basic(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNextDNSPrivacyConfig(true, `["nextdns-recommended", "oisd"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_privacy.test", "disguised_trackers", "true"),
+					resource.TestCheckResourceAttr("nextdns_privacy.test", "blocklists.#", "2"),
+					resource.TestCheckResourceAttr("nextdns_privacy.test", "natives.#", "1"),
+				),
+			},
+			{
+				Config: testAccNextDNSPrivacyConfig(false, `["oisd"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_privacy.test", "disguised_trackers", "false"),
+					resource.TestCheckResourceAttr("nextdns_privacy.test", "blocklists.#", "1"),
+					testAccCheckProfile(s, "nextdns_privacy.test", func(profile map[string]interface{}) error {
+						if blocklists := profile["privacy"].(map[string]interface{})["blocklists"].([]interface{}); len(blocklists) != 1 {
+							return fmt.Errorf("unexpected blocklists %v", blocklists)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:      "nextdns_privacy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNextDNSPrivacyConfig(disguisedTrackers bool, blocklists string) string {
+	return testAccProfileConfig() + fmt.Sprintf(`
+resource "nextdns_privacy" "test" {
+  profile_id = nextdns_profile.test.id
+
+  disguised_trackers = %[1]t
+  allow_affiliate    = false
+
+  blocklists = %[2]s
+  natives    = ["apple"]
+}
+`, disguisedTrackers, blocklists)
+}
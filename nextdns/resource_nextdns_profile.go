@@ -7,6 +7,7 @@ import (
 	"github.com/amalucelli/nextdns-go/nextdns"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -17,6 +18,10 @@ func resourceNextDNSProfile() *schema.Resource {
 		ReadContext:   resourceNextDNSProfileRead,
 		UpdateContext: resourceNextDNSProfileUpdate,
 		DeleteContext: resourceNextDNSProfileDelete,
+		CustomizeDiff: customdiff.Sequence(
+			customizeDiffRecreation("parental_control.0.recreation"),
+			customizeDiffParentalControlCatalog("parental_control.0."),
+		),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceNextDNSProfileImport,
 		},
@@ -24,16 +29,17 @@ func resourceNextDNSProfile() *schema.Resource {
 }
 
 func resourceNextDNSProfileCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 
-	request := &nextdns.CreateProfileRequest{
-		Name: d.Get("name").(string),
+	request, err := buildProfile(d)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error building profile: %w", err))
 	}
 	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
 
 	profileID, err := client.Profiles.Create(ctx, request)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error creating profile: %w", err))
+		return apiErrorDiags(err, "error creating profile", profileErrorTargets(d)...)
 	}
 
 	d.SetId(profileID)
@@ -43,17 +49,15 @@ func resourceNextDNSProfileCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceNextDNSProfileRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	profiles := meta.(*providerMeta).profiles
 	profileID := d.Get("profile_id").(string)
 
-	request := &nextdns.GetProfileRequest{
-		ProfileID: profileID,
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
-
-	profile, err := client.Profiles.Get(ctx, request)
+	profile, err := profiles.get(ctx, profileID)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error getting profile: %w", err))
+		if isNotFound(err) {
+			return removeNotFound(ctx, d, "nextdns_profile")
+		}
+		return apiErrorDiags(err, "error getting profile")
 	}
 	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", profile))
 
@@ -61,15 +65,49 @@ func resourceNextDNSProfileRead(ctx context.Context, d *schema.ResourceData, met
 		return diag.FromErr(err)
 	}
 
+	// Only the blocks present in the configuration are managed by the resource.
+	values := make(map[string]interface{})
+	if _, ok := d.GetOk("security"); ok && profile.Security != nil {
+		values["security"] = []interface{}{flattenSecurity(profile.Security)}
+	}
+	if _, ok := d.GetOk("privacy"); ok && profile.Privacy != nil {
+		values["privacy"] = []interface{}{flattenPrivacy(profile.Privacy)}
+	}
+	if _, ok := d.GetOk("parental_control"); ok && profile.ParentalControl != nil {
+		parentalControl := flattenParentalControl(profile.ParentalControl, d.Get("parental_control.0.recreation"))
+		// The flag is only known by the configuration.
+		parentalControl["allow_unknown_ids"] = d.Get("parental_control.0.allow_unknown_ids")
+		values["parental_control"] = []interface{}{parentalControl}
+	}
+	if _, ok := d.GetOk("denylist"); ok {
+		values["denylist"] = []interface{}{map[string]interface{}{"domain": flattenDenylist(profile.Denylist)}}
+	}
+	if _, ok := d.GetOk("allowlist"); ok {
+		values["allowlist"] = []interface{}{map[string]interface{}{"domain": flattenAllowlist(profile.Allowlist)}}
+	}
+	if _, ok := d.GetOk("settings"); ok && profile.Settings != nil {
+		values["settings"] = []interface{}{flattenSettings(profile.Settings)}
+	}
+	if _, ok := d.GetOk("rewrite"); ok {
+		values["rewrite"] = flattenRewrites(profile.Rewrites)
+	}
+
+	if diags := setFlattened(d, values); diags.HasError() {
+		return diags
+	}
+
 	d.SetId(profileID)
 
 	return nil
 }
 
 func resourceNextDNSProfileUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
 	profile := &nextdns.Profile{
 		Name: d.Get("name").(string),
 	}
@@ -83,16 +121,23 @@ func resourceNextDNSProfileUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	err := client.Profiles.Update(ctx, request)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error updating profile: %w", err))
+		return apiErrorDiags(err, "error updating profile")
+	}
+
+	if diags := updateProfileSections(ctx, client, d); diags.HasError() {
+		return diags
 	}
 
 	return resourceNextDNSProfileRead(ctx, d, meta)
 }
 
 func resourceNextDNSProfileDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
 	request := &nextdns.DeleteProfileRequest{
 		ProfileID: profileID,
 	}
@@ -100,7 +145,10 @@ func resourceNextDNSProfileDelete(ctx context.Context, d *schema.ResourceData, m
 
 	err := client.Profiles.Delete(ctx, request)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error deleting profile: %w", err))
+		if isNotFound(err) {
+			return nil
+		}
+		return apiErrorDiags(err, "error deleting profile")
 	}
 
 	return nil
@@ -115,3 +163,279 @@ func resourceNextDNSProfileImport(ctx context.Context, d *schema.ResourceData, m
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// buildProfile returns the request creating the profile with every configured block.
+func buildProfile(d *schema.ResourceData) (*nextdns.CreateProfileRequest, error) {
+	var err error
+
+	request := &nextdns.CreateProfileRequest{
+		Name: d.Get("name").(string),
+	}
+
+	if _, ok := d.GetOk("security"); ok {
+		if request.Security, err = buildSecurity(newNestedData(d, "security")); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := d.GetOk("privacy"); ok {
+		if request.Privacy, err = buildPrivacy(newNestedData(d, "privacy")); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := d.GetOk("parental_control"); ok {
+		if request.ParentalControl, err = buildParentalControl(newNestedData(d, "parental_control")); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := d.GetOk("denylist"); ok {
+		if request.Denylist, err = buildDenylist(newNestedData(d, "denylist")); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := d.GetOk("allowlist"); ok {
+		if request.Allowlist, err = buildAllowlist(newNestedData(d, "allowlist")); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := d.GetOk("settings"); ok {
+		if request.Settings, err = buildSettings(newNestedData(d, "settings")); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := d.GetOk("rewrite"); ok {
+		if request.Rewrites, err = buildRewrite(d); err != nil {
+			return nil, err
+		}
+	}
+
+	return request, nil
+}
+
+// profileErrorTargets returns the targets of the lists declared in the nested blocks of the profile,
+// as sent in the request creating it.
+func profileErrorTargets(d *schema.ResourceData) []apiErrorTarget {
+	return []apiErrorTarget{
+		listErrorTarget("/security/tlds", d, "security.0.tlds"),
+		listErrorTarget("/privacy/blocklists", d, "privacy.0.blocklists"),
+		listErrorTarget("/privacy/natives", d, "privacy.0.natives"),
+		setErrorTarget("/parentalControl/services", d, "parental_control.0.service", "id"),
+		setErrorTarget("/parentalControl/categories", d, "parental_control.0.category", "id"),
+		setErrorTarget("/denylist", d, "denylist.0.domain", "id"),
+		setErrorTarget("/allowlist", d, "allowlist.0.domain", "id"),
+		setErrorTarget("/rewrites", d, "rewrite", "domain"),
+	}
+}
+
+// updateProfileSections writes the configured blocks that changed through their own endpoints.
+// A block removed from the configuration is no longer managed and is left as is.
+func updateProfileSections(ctx context.Context, client *nextdns.Client, d *schema.ResourceData) diag.Diagnostics {
+	profileID := d.Get("profile_id").(string)
+
+	changed := func(block string) bool {
+		_, ok := d.GetOk(block)
+		return ok && d.HasChange(block)
+	}
+
+	if changed("security") {
+		nested := newNestedData(d, "security")
+		sec, err := buildSecurity(nested)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error updating security settings: %w", err))
+		}
+
+		if nested.HasChanges("tlds") {
+			tlds := &nextdns.CreateSecurityTldsRequest{
+				ProfileID:    profileID,
+				SecurityTlds: sec.Tlds,
+			}
+			tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", tlds))
+
+			if err := client.SecurityTlds.Create(ctx, tlds); err != nil {
+				return apiErrorDiags(err, "error updating security tlds settings", listErrorTarget("", d, "security.0.tlds"))
+			}
+		}
+
+		if nested.HasChanges(securitySettings...) {
+			sec.Tlds = nil
+
+			request := &nextdns.UpdateSecurityRequest{
+				ProfileID: profileID,
+				Security:  sec,
+			}
+			tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+
+			if err := client.Security.Update(ctx, request); err != nil {
+				return apiErrorDiags(err, "error updating security settings")
+			}
+		}
+	}
+
+	if changed("privacy") {
+		nested := newNestedData(d, "privacy")
+		privacy, err := buildPrivacy(nested)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error updating privacy settings: %w", err))
+		}
+
+		if nested.HasChanges("blocklists") {
+			blocklists := &nextdns.CreatePrivacyBlocklistsRequest{
+				ProfileID:         profileID,
+				PrivacyBlocklists: privacy.Blocklists,
+			}
+			tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", blocklists))
+
+			if err := client.PrivacyBlocklists.Create(ctx, blocklists); err != nil {
+				return apiErrorDiags(err, "error updating blocklist settings", listErrorTarget("", d, "privacy.0.blocklists"))
+			}
+		}
+
+		if nested.HasChanges("natives") {
+			natives := &nextdns.CreatePrivacyNativesRequest{
+				ProfileID:      profileID,
+				PrivacyNatives: privacy.Natives,
+			}
+			tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", natives))
+
+			if err := client.PrivacyNatives.Create(ctx, natives); err != nil {
+				return apiErrorDiags(err, "error updating native settings", listErrorTarget("", d, "privacy.0.natives"))
+			}
+		}
+
+		if nested.HasChanges(privacySettings...) {
+			privacy.Blocklists = nil
+			privacy.Natives = nil
+
+			request := &nextdns.UpdatePrivacyRequest{
+				ProfileID: profileID,
+				Privacy:   privacy,
+			}
+			tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+
+			if err := client.Privacy.Update(ctx, request); err != nil {
+				return apiErrorDiags(err, "error updating privacy settings")
+			}
+		}
+	}
+
+	if changed("parental_control") {
+		nested := newNestedData(d, "parental_control")
+		parentalControl, err := buildParentalControl(nested)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error updating parental control settings: %w", err))
+		}
+
+		if nested.HasChanges("service") {
+			services := &nextdns.CreateParentalControlServicesRequest{
+				ProfileID:               profileID,
+				ParentalControlServices: parentalControl.Services,
+			}
+			tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", services))
+
+			if err := client.ParentalControlServices.Create(ctx, services); err != nil {
+				return apiErrorDiags(err, "error updating services settings", setErrorTarget("", d, "parental_control.0.service", "id"))
+			}
+		}
+
+		if nested.HasChanges("category") {
+			categories := &nextdns.CreateParentalControlCategoriesRequest{
+				ProfileID:                 profileID,
+				ParentalControlCategories: parentalControl.Categories,
+			}
+			tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", categories))
+
+			if err := client.ParentalControlCategories.Create(ctx, categories); err != nil {
+				return apiErrorDiags(err, "error updating categories settings", setErrorTarget("", d, "parental_control.0.category", "id"))
+			}
+		}
+
+		if nested.HasChanges(parentalControlSettings...) {
+			parentalControl.Services = nil
+			parentalControl.Categories = nil
+
+			request := &nextdns.UpdateParentalControlRequest{
+				ProfileID:       profileID,
+				ParentalControl: parentalControl,
+			}
+			tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+
+			if err := client.ParentalControl.Update(ctx, request); err != nil {
+				return apiErrorDiags(err, "error updating parental control settings")
+			}
+		}
+	}
+
+	if changed("denylist") {
+		denylist, err := buildDenylist(newNestedData(d, "denylist"))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error building deny list: %w", err))
+		}
+
+		request := &nextdns.CreateDenylistRequest{
+			ProfileID: profileID,
+			Denylist:  denylist,
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+
+		if err := client.Denylist.Create(ctx, request); err != nil {
+			return apiErrorDiags(err, "error updating deny list", setErrorTarget("", d, "denylist.0.domain", "id"))
+		}
+	}
+
+	if changed("allowlist") {
+		allowlist, err := buildAllowlist(newNestedData(d, "allowlist"))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error building allow list: %w", err))
+		}
+
+		request := &nextdns.CreateAllowlistRequest{
+			ProfileID: profileID,
+			Allowlist: allowlist,
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+
+		if err := client.Allowlist.Create(ctx, request); err != nil {
+			return apiErrorDiags(err, "error updating allow list", setErrorTarget("", d, "allowlist.0.domain", "id"))
+		}
+	}
+
+	if changed("settings") {
+		nested := newNestedData(d, "settings")
+		settings, err := buildSettings(nested)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error updating settings: %w", err))
+		}
+
+		if !nested.HasChanges("logs") {
+			settings.Logs = nil
+		}
+		if !nested.HasChanges("block_page") {
+			settings.BlockPage = nil
+		}
+		if !nested.HasChanges("performance") {
+			settings.Performance = nil
+		}
+
+		request := &nextdns.UpdateSettingsRequest{
+			ProfileID: profileID,
+			Settings:  settings,
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+
+		if err := client.Settings.Update(ctx, request); err != nil {
+			return apiErrorDiags(err, "error updating settings")
+		}
+	}
+
+	if changed("rewrite") {
+		rewrites, err := buildRewrite(d)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error building rewrite list: %w", err))
+		}
+
+		if err := syncRewrites(ctx, client, profileID, rewrites); err != nil {
+			return apiErrorDiags(err, "error updating rewrites", setErrorTarget("", d, "rewrite", "domain"))
+		}
+	}
+
+	return nil
+}
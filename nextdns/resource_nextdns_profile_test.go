@@ -0,0 +1,173 @@
+package nextdns
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNextDNSProfile_basic(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNextDNSProfileConfig("first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_profile.test", "name", "first"),
+					resource.TestCheckResourceAttrSet("nextdns_profile.test", "profile_id"),
+				),
+			},
+			{
+				Config: testAccNextDNSProfileConfig("second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_profile.test", "name", "second"),
+					testAccCheckProfile(s, "nextdns_profile.test", func(profile map[string]interface{}) error {
+						if profile["name"] != "second" {
+							return fmt.Errorf("unexpected name %v", profile["name"])
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:      "nextdns_profile.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNextDNSProfile_full(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNextDNSProfileFullConfig("example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_profile.test", "security.0.nrd", "true"),
+					resource.TestCheckResourceAttr("nextdns_profile.test", "security.0.tlds.#", "1"),
+					resource.TestCheckResourceAttr("nextdns_profile.test", "denylist.0.domain.#", "1"),
+					resource.TestCheckResourceAttr("nextdns_profile.test", "rewrite.#", "1"),
+					testAccCheckProfile(s, "nextdns_profile.test", func(profile map[string]interface{}) error {
+						if profile["security"].(map[string]interface{})["nrd"] != true {
+							return fmt.Errorf("unexpected security settings %v", profile["security"])
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config: testAccNextDNSProfileFullConfig("example.org"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_profile.test", "denylist.0.domain.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("nextdns_profile.test", "denylist.0.domain.*", map[string]string{
+						"id": "example.org",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("nextdns_profile.test", "rewrite.*", map[string]string{
+						"domain": "example.org",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNextDNSProfile_catalog(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNextDNSProfileCatalogConfig("allow_unknown_ids = false"),
+				ExpectError: regexp.MustCompile(`unknown service "tiktock", did you mean "tiktok"\?`),
+			},
+			{
+				Config:      testAccNextDNSProfileCatalogConfig(""),
+				ExpectError: regexp.MustCompile(`unknown service "tiktock", did you mean "tiktok"\?`),
+			},
+			{
+				Config: testAccNextDNSProfileCatalogConfig("allow_unknown_ids = true"),
+				Check: resource.TestCheckTypeSetElemNestedAttrs("nextdns_profile.test", "parental_control.0.service.*", map[string]string{
+					"id": "tiktock",
+				}),
+			},
+		},
+	})
+}
+
+func testAccNextDNSProfileConfig(name string) string {
+	return fmt.Sprintf(`
+resource "nextdns_profile" "test" {
+  name = %[1]q
+}
+`, name)
+}
+
+func testAccNextDNSProfileFullConfig(domain string) string {
+	return fmt.Sprintf(`
+resource "nextdns_profile" "test" {
+  name = "terraform-acc-test"
+
+  security {
+    threat_intelligence_feeds = true
+    ai_threat_detection       = true
+    google_safe_browsing      = true
+    crypto_jacking            = true
+    dns_rebinding             = true
+    idn_homographs            = true
+    typo_squatting            = true
+    dga                       = true
+    nrd                       = true
+    ddns                      = false
+    parking                   = true
+    csam                      = true
+    tlds                      = ["ru"]
+  }
+
+  denylist {
+    domain {
+      id     = %[1]q
+      active = true
+    }
+  }
+
+  rewrite {
+    domain  = %[1]q
+    address = "192.0.2.1"
+  }
+}
+`, domain)
+}
+
+func testAccNextDNSProfileCatalogConfig(allowUnknownIDs string) string {
+	return fmt.Sprintf(`
+resource "nextdns_profile" "test" {
+  name = "terraform-acc-test"
+
+  parental_control {
+    %[1]s
+
+    safe_search             = true
+    youtube_restricted_mode = false
+    block_bypass            = true
+
+    service {
+      id         = "tiktock"
+      active     = true
+      recreation = false
+    }
+  }
+}
+`, allowUnknownIDs)
+}
@@ -25,9 +25,12 @@ func resourceNextDNSRewrite() *schema.Resource {
 }
 
 func resourceNextDNSRewriteCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
 	rewrites, err := buildRewrite(d)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error building rewrite list: %w", err))
@@ -41,7 +44,7 @@ func resourceNextDNSRewriteCreate(ctx context.Context, d *schema.ResourceData, m
 
 	existing, err := client.Rewrites.List(ctx, list)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error getting rewrites: %w", err))
+		return apiErrorDiags(err, "error getting rewrites")
 	}
 	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", rewrites))
 
@@ -60,7 +63,7 @@ func resourceNextDNSRewriteCreate(ctx context.Context, d *schema.ResourceData, m
 
 				err := client.Rewrites.Delete(ctx, deleteRequest)
 				if err != nil {
-					return diag.FromErr(fmt.Errorf("error deleting rewrite: %w", err))
+					return apiErrorDiags(err, "error deleting rewrite")
 				}
 
 				continue
@@ -77,7 +80,8 @@ func resourceNextDNSRewriteCreate(ctx context.Context, d *schema.ResourceData, m
 
 		_, err = client.Rewrites.Create(ctx, request)
 		if err != nil {
-			return diag.FromErr(fmt.Errorf("error creating rewrite: %w", err))
+			err = &entryError{action: "creating", id: rewrite.Name, err: err}
+			return apiErrorDiags(err, "error creating rewrite", setErrorTarget("", d, "rewrite", "domain"))
 		}
 	}
 
@@ -87,9 +91,56 @@ func resourceNextDNSRewriteCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceNextDNSRewriteRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	profiles := meta.(*providerMeta).profiles
+	profileID := d.Get("profile_id").(string)
+
+	profile, err := profiles.get(ctx, profileID)
+	if err != nil {
+		if isNotFound(err) {
+			return removeNotFound(ctx, d, "nextdns_rewrite")
+		}
+		return apiErrorDiags(err, "error getting rewrites")
+	}
+	rewrites := profile.Rewrites
+	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", rewrites))
+
+	if err := d.Set("rewrite", flattenRewrites(rewrites)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(profileID)
+
+	return nil
+}
+
+func resourceNextDNSRewriteUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	profileID := d.Get("profile_id").(string)
+
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
+	rewrites, err := buildRewrite(d)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error building rewrite list: %w", err))
+	}
+	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", rewrites))
+
+	err = syncRewrites(ctx, client, profileID, rewrites)
+	if err != nil {
+		return apiErrorDiags(err, "error updating rewrites", setErrorTarget("", d, "rewrite", "domain"))
+	}
+
+	return resourceNextDNSRewriteRead(ctx, d, meta)
+}
+
+func resourceNextDNSRewriteDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
 	request := &nextdns.ListRewritesRequest{
 		ProfileID: profileID,
 	}
@@ -97,38 +148,76 @@ func resourceNextDNSRewriteRead(ctx context.Context, d *schema.ResourceData, met
 
 	rewrites, err := client.Rewrites.List(ctx, request)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error getting rewrites: %w", err))
+		if isNotFound(err) {
+			return nil
+		}
+		return apiErrorDiags(err, "error getting rewrites")
 	}
 	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", rewrites))
 
+	for _, rewrite := range rewrites {
+		request := &nextdns.DeleteRewritesRequest{
+			ProfileID: profileID,
+			ID:        rewrite.ID,
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+
+		err = client.Rewrites.Delete(ctx, request)
+		if err != nil {
+			return apiErrorDiags(err, "error deleting rewrite")
+		}
+	}
+
+	return resourceNextDNSRewriteRead(ctx, d, meta)
+}
+
+func resourceNextDNSRewriteImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	profileID := d.Id()
+	d.SetId(profileID)
+	d.Set("profile_id", profileID)
+
+	resourceNextDNSRewriteRead(ctx, d, meta)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// flattenRewrites returns the rewrite blocks of the rewrites.
+func flattenRewrites(rewrites []*nextdns.Rewrites) []map[string]interface{} {
 	var rewrite []map[string]interface{}
 
-	for _, d := range rewrites {
+	for _, r := range rewrites {
 		record := make(map[string]interface{})
-		record["domain"] = d.Name
-		record["address"] = d.Content
+		record["domain"] = r.Name
+		record["address"] = r.Content
 
 		rewrite = append(rewrite, record)
 	}
-	if err := d.Set("rewrite", rewrite); err != nil {
-		return diag.FromErr(err)
-	}
-
-	d.SetId(profileID)
 
-	return nil
+	return rewrite
 }
 
-func resourceNextDNSRewriteUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
-	profileID := d.Get("profile_id").(string)
+func buildRewrite(d resourceData) ([]*nextdns.Rewrites, error) {
+	found, ok := d.GetOk("rewrite")
+	if !ok {
+		// nolint:goerr113
+		return nil, errors.New("unable to find rewrite in resource data")
+	}
 
-	rewrites, err := buildRewrite(d)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error building rewrite list: %w", err))
+	records := found.(*schema.Set).List()
+
+	rewrites := make([]*nextdns.Rewrites, len(records))
+	for k, v := range records {
+		rewrites[k] = &nextdns.Rewrites{
+			Name:    v.(map[string]interface{})["domain"].(string),
+			Content: v.(map[string]interface{})["address"].(string),
+		}
 	}
-	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", rewrites))
 
+	return rewrites, nil
+}
+
+// syncRewrites deletes the existing rewrites that are not in the given list and creates the missing ones.
+func syncRewrites(ctx context.Context, client *nextdns.Client, profileID string, rewrites []*nextdns.Rewrites) error {
 	list := &nextdns.ListRewritesRequest{
 		ProfileID: profileID,
 	}
@@ -136,9 +225,9 @@ func resourceNextDNSRewriteUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	existing, err := client.Rewrites.List(ctx, list)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error getting rewrites: %w", err))
+		return fmt.Errorf("error getting rewrites: %w", err)
 	}
-	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", rewrites))
+	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", existing))
 
 	var toRemove []*nextdns.Rewrites
 	var toAdd []*nextdns.Rewrites
@@ -178,7 +267,7 @@ func resourceNextDNSRewriteUpdate(ctx context.Context, d *schema.ResourceData, m
 
 		err := client.Rewrites.Delete(ctx, deleteRequest)
 		if err != nil {
-			return diag.FromErr(fmt.Errorf("error deleting rewrite: %w", err))
+			return &entryError{action: "deleting", id: r.Name, err: err}
 		}
 	}
 
@@ -189,72 +278,11 @@ func resourceNextDNSRewriteUpdate(ctx context.Context, d *schema.ResourceData, m
 		}
 		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
 
-		_, err = client.Rewrites.Create(ctx, request)
-		if err != nil {
-			return diag.FromErr(fmt.Errorf("error creating rewrite: %w", err))
-		}
-	}
-
-	return resourceNextDNSRewriteRead(ctx, d, meta)
-}
-
-func resourceNextDNSRewriteDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
-	profileID := d.Get("profile_id").(string)
-
-	request := &nextdns.ListRewritesRequest{
-		ProfileID: profileID,
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
-
-	rewrites, err := client.Rewrites.List(ctx, request)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error getting rewrites: %w", err))
-	}
-	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", rewrites))
-
-	for _, rewrite := range rewrites {
-		request := &nextdns.DeleteRewritesRequest{
-			ProfileID: profileID,
-			ID:        rewrite.ID,
-		}
-		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
-
-		err = client.Rewrites.Delete(ctx, request)
+		_, err := client.Rewrites.Create(ctx, request)
 		if err != nil {
-			return diag.FromErr(fmt.Errorf("error deleting rewrite: %w", err))
+			return &entryError{action: "creating", id: r.Name, err: err}
 		}
 	}
 
-	return resourceNextDNSRewriteRead(ctx, d, meta)
-}
-
-func resourceNextDNSRewriteImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	profileID := d.Id()
-	d.SetId(profileID)
-	d.Set("profile_id", profileID)
-
-	resourceNextDNSRewriteRead(ctx, d, meta)
-
-	return []*schema.ResourceData{d}, nil
-}
-
-func buildRewrite(d *schema.ResourceData) ([]*nextdns.Rewrites, error) {
-	found, ok := d.GetOk("rewrite")
-	if !ok {
-		// nolint:goerr113
-		return nil, errors.New("unable to find rewrite in resource data")
-	}
-
-	records := found.(*schema.Set).List()
-
-	rewrites := make([]*nextdns.Rewrites, len(records))
-	for k, v := range records {
-		rewrites[k] = &nextdns.Rewrites{
-			Name:    v.(map[string]interface{})["domain"].(string),
-			Content: v.(map[string]interface{})["address"].(string),
-		}
-	}
-
-	return rewrites, nil
+	return nil
 }
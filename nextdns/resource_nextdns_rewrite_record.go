@@ -0,0 +1,200 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/amalucelli/nextdns-go/nextdns"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// rewritesAPIPath is the path of the rewrites within a profile.
+const rewritesAPIPath = "rewrites"
+
+func resourceNextDNSRewriteRecord() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceNextDNSRewriteRecordSchema(),
+		CreateContext: resourceNextDNSRewriteRecordCreate,
+		ReadContext:   resourceNextDNSRewriteRecordRead,
+		UpdateContext: resourceNextDNSRewriteRecordUpdate,
+		DeleteContext: resourceNextDNSRewriteRecordDelete,
+		// The type of record is detected from the address by the API.
+		CustomizeDiff: customdiff.ComputedIf("type", func(_ context.Context, d *schema.ResourceDiff, _ interface{}) bool {
+			return d.HasChange("address")
+		}),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceNextDNSRewriteRecordImport,
+		},
+	}
+}
+
+func resourceNextDNSRewriteRecordCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	profileID := d.Get("profile_id").(string)
+
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
+	request := &nextdns.CreateRewritesRequest{
+		ProfileID: profileID,
+		Rewrites: &nextdns.Rewrites{
+			Name:    d.Get("domain").(string),
+			Content: d.Get("address").(string),
+		},
+	}
+	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+
+	recordID, err := client.Rewrites.Create(ctx, request)
+	if err != nil {
+		return apiErrorDiags(err, "error creating rewrite",
+			fieldErrorTarget("/name", d, "domain"),
+			fieldErrorTarget("/content", d, "address"),
+		)
+	}
+
+	d.SetId(profileID + "/" + recordID)
+	d.Set("record_id", recordID)
+
+	return resourceNextDNSRewriteRecordRead(ctx, d, meta)
+}
+
+func resourceNextDNSRewriteRecordRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	profiles := meta.(*providerMeta).profiles
+	profileID := d.Get("profile_id").(string)
+	recordID := d.Get("record_id").(string)
+
+	profile, err := profiles.get(ctx, profileID)
+	if err != nil {
+		if isNotFound(err) {
+			return removeNotFound(ctx, d, "nextdns_rewrite_record")
+		}
+		return apiErrorDiags(err, "error getting rewrites")
+	}
+	rewrites := profile.Rewrites
+	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", rewrites))
+
+	var record *nextdns.Rewrites
+	for _, r := range rewrites {
+		if r.ID == recordID {
+			record = r
+			break
+		}
+	}
+
+	if record == nil {
+		return removeFromState(ctx, d,
+			"nextdns_rewrite_record not found",
+			fmt.Sprintf("The rewrite %q no longer exists in the profile %q, so it was removed from the state and will be recreated on the next apply.", recordID, profileID),
+		)
+	}
+
+	d.Set("domain", record.Name)
+	d.Set("address", record.Content)
+	d.Set("type", record.Type)
+
+	return nil
+}
+
+func resourceNextDNSRewriteRecordUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	api := meta.(*providerMeta).api
+	profileID := d.Get("profile_id").(string)
+	recordID := d.Get("record_id").(string)
+
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
+	rewrite := &nextdns.Rewrites{
+		Name:    d.Get("domain").(string),
+		Content: d.Get("address").(string),
+	}
+	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", rewrite))
+
+	err := api.do(ctx, http.MethodPatch, profilePath(profileID, rewritesAPIPath, recordID), rewrite, nil)
+	if err != nil {
+		return apiErrorDiags(err, "error updating rewrite",
+			fieldErrorTarget("/name", d, "domain"),
+			fieldErrorTarget("/content", d, "address"),
+		)
+	}
+
+	return resourceNextDNSRewriteRecordRead(ctx, d, meta)
+}
+
+func resourceNextDNSRewriteRecordDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	profileID := d.Get("profile_id").(string)
+
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
+	request := &nextdns.DeleteRewritesRequest{
+		ProfileID: profileID,
+		ID:        d.Get("record_id").(string),
+	}
+	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+
+	err := client.Rewrites.Delete(ctx, request)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return apiErrorDiags(err, "error deleting rewrite")
+	}
+
+	return nil
+}
+
+// resourceNextDNSRewriteRecordImport imports a rewrite either by <profile_id>/<record_id> or <profile_id>/<domain>.
+func resourceNextDNSRewriteRecordImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*providerMeta).client
+
+	profileID, part, err := parseTwoPartID(d.Id(), "record_id|domain")
+	if err != nil {
+		return nil, err
+	}
+
+	request := &nextdns.ListRewritesRequest{
+		ProfileID: profileID,
+	}
+	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+
+	rewrites, err := client.Rewrites.List(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rewrites: %w", err)
+	}
+
+	var matches []*nextdns.Rewrites
+	for _, r := range rewrites {
+		if r.ID == part {
+			matches = []*nextdns.Rewrites{r}
+			break
+		}
+		if r.Name == part {
+			matches = append(matches, r)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		// nolint:goerr113
+		return nil, fmt.Errorf("no rewrite found with id or domain %q in profile %q", part, profileID)
+	case 1:
+	default:
+		// nolint:goerr113
+		return nil, fmt.Errorf("multiple rewrites found for domain %q in profile %q, import it by record id instead", part, profileID)
+	}
+
+	d.SetId(profileID + "/" + matches[0].ID)
+	d.Set("profile_id", profileID)
+	d.Set("record_id", matches[0].ID)
+
+	if err := importReadError(d, resourceNextDNSRewriteRecordRead(ctx, d, meta)); err != nil {
+		return nil, fmt.Errorf("error importing rewrite %q of profile %q: %w", matches[0].ID, profileID, err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,126 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccNextDNSRewriteRecord_basic(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNextDNSRewriteRecordConfig("192.0.2.1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_rewrite_record.test", "domain", "example.com"),
+					resource.TestCheckResourceAttr("nextdns_rewrite_record.test", "type", "A"),
+					resource.TestCheckResourceAttrSet("nextdns_rewrite_record.test", "record_id"),
+				),
+			},
+			{
+				Config: testAccNextDNSRewriteRecordConfig("2001:db8::1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_rewrite_record.test", "address", "2001:db8::1"),
+					resource.TestCheckResourceAttr("nextdns_rewrite_record.test", "type", "AAAA"),
+					testAccCheckProfile(s, "nextdns_rewrite_record.test", func(profile map[string]interface{}) error {
+						if rewrites := profile["rewrites"].([]interface{}); len(rewrites) != 1 {
+							return fmt.Errorf("unexpected rewrites %v", rewrites)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:      "nextdns_rewrite_record.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNextDNSRewriteRecordConfig(address string) string {
+	return testAccProfileConfig() + fmt.Sprintf(`
+resource "nextdns_rewrite_record" "test" {
+  profile_id = nextdns_profile.test.id
+  domain     = "example.com"
+  address    = %[1]q
+}
+`, address)
+}
+
+func TestResourceNextDNSRewriteRecordImport_readError(t *testing.T) {
+	// The rewrite is listed, but the profile cannot be read afterwards.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/profiles/abc123/rewrites" {
+			_, _ = w.Write([]byte(`{"data": [{"id": "r1", "name": "example.com", "content": "192.0.2.1", "type": "A"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors": [{"code": "notFound"}]}`))
+	}))
+	defer server.Close()
+
+	meta, err := newProviderMeta(&clientConfig{apiKey: "test", apiURL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := resourceNextDNSRewriteRecord()
+	d := r.Data(nil)
+	d.SetId("abc123/example.com")
+
+	if _, err := r.Importer.StateContext(context.Background(), d, meta); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestResourceNextDNSRewriteRecordDiff_type(t *testing.T) {
+	r := resourceNextDNSRewriteRecord()
+	state := &terraform.InstanceState{
+		ID: "abc123/r1",
+		Attributes: map[string]string{
+			"id":         "abc123/r1",
+			"profile_id": "abc123",
+			"domain":     "example.com",
+			"address":    "192.0.2.1",
+			"record_id":  "r1",
+			"type":       "A",
+		},
+	}
+
+	tests := []struct {
+		address  string
+		computed bool
+	}{
+		{address: "192.0.2.1", computed: false},
+		{address: "2001:db8::1", computed: true},
+	}
+
+	for _, tt := range tests {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"profile_id": "abc123",
+			"domain":     "example.com",
+			"address":    tt.address,
+		})
+
+		diff, err := r.Diff(context.Background(), state, config, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		computed := diff != nil && diff.Attributes["type"] != nil && diff.Attributes["type"].NewComputed
+		if computed != tt.computed {
+			t.Errorf("type recomputed for address %s = %t, want %t", tt.address, computed, tt.computed)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package nextdns
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNextDNSRewrite_basic(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNextDNSRewriteConfig("192.0.2.1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_rewrite.test", "rewrite.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("nextdns_rewrite.test", "rewrite.*", map[string]string{
+						"domain":  "example.com",
+						"address": "192.0.2.1",
+					}),
+				),
+			},
+			{
+				Config: testAccNextDNSRewriteConfig("2001:db8::1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_rewrite.test", "rewrite.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("nextdns_rewrite.test", "rewrite.*", map[string]string{
+						"domain":  "example.com",
+						"address": "2001:db8::1",
+					}),
+					testAccCheckProfile(s, "nextdns_rewrite.test", func(profile map[string]interface{}) error {
+						if rewrites := profile["rewrites"].([]interface{}); len(rewrites) != 2 {
+							return fmt.Errorf("unexpected rewrites %v", rewrites)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:      "nextdns_rewrite.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNextDNSRewriteConfig(address string) string {
+	return testAccProfileConfig() + fmt.Sprintf(`
+resource "nextdns_rewrite" "test" {
+  profile_id = nextdns_profile.test.id
+
+  rewrite {
+    domain  = "example.com"
+    address = %[1]q
+  }
+
+  rewrite {
+    domain  = "example.org"
+    address = "example.net"
+  }
+}
+`, address)
+}
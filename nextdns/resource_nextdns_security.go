@@ -10,6 +10,25 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// securityTldsAPIPath is the path of the blocked TLDs within a profile.
+const securityTldsAPIPath = "security/tlds"
+
+// securitySettings are the attributes written to the security settings, the TLDs having their own endpoint.
+var securitySettings = []string{
+	"threat_intelligence_feeds",
+	"ai_threat_detection",
+	"google_safe_browsing",
+	"crypto_jacking",
+	"dns_rebinding",
+	"idn_homographs",
+	"typo_squatting",
+	"dga",
+	"nrd",
+	"ddns",
+	"parking",
+	"csam",
+}
+
 func resourceNextDNSSecurity() *schema.Resource {
 	return &schema.Resource{
 		Schema:        resourceNextDNSSecuritySchema(),
@@ -24,24 +43,34 @@ func resourceNextDNSSecurity() *schema.Resource {
 }
 
 func resourceNextDNSSecurityCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
 	sec, err := buildSecurity(d)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error creating security settings: %w", err))
 	}
 	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", sec))
 
-	tlds := &nextdns.CreateSecurityTldsRequest{
-		ProfileID:    profileID,
-		SecurityTlds: sec.Tlds,
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", tlds))
+	if d.Get("authoritative").(bool) {
+		tlds := &nextdns.CreateSecurityTldsRequest{
+			ProfileID:    profileID,
+			SecurityTlds: sec.Tlds,
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", tlds))
+
+		err = client.SecurityTlds.Create(ctx, tlds)
+	} else {
+		err = syncSecurityTlds(ctx, meta.(*providerMeta), profileID, nil, d.Get("tlds"))
 
-	err = client.SecurityTlds.Create(ctx, tlds)
+		// The TLDs must not be sent with the settings, otherwise they would replace the whole list.
+		sec.Tlds = nil
+	}
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error creating security tlds settings: %w", err))
+		return apiErrorDiags(err, "error creating security tlds settings", listErrorTarget("", d, "tlds"))
 	}
 
 	request := &nextdns.UpdateSecurityRequest{
@@ -52,7 +81,7 @@ func resourceNextDNSSecurityCreate(ctx context.Context, d *schema.ResourceData,
 
 	err = client.Security.Update(ctx, request)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error creating security settings: %w", err))
+		return apiErrorDiags(err, "error creating security settings", listErrorTarget("/tlds", d, "tlds"))
 	}
 
 	d.SetId(profileID)
@@ -61,84 +90,110 @@ func resourceNextDNSSecurityCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceNextDNSSecurityRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	profiles := meta.(*providerMeta).profiles
 	profileID := d.Get("profile_id").(string)
 
-	request := &nextdns.GetSecurityRequest{
-		ProfileID: profileID,
-	}
-	security, err := client.Security.Get(ctx, request)
+	profile, err := profiles.get(ctx, profileID)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error getting security settings: %w", err))
+		if isNotFound(err) {
+			return removeNotFound(ctx, d, "nextdns_security")
+		}
+		return apiErrorDiags(err, "error getting security settings")
+	}
+	security := profile.Security
+	if security == nil {
+		return diag.Errorf("error getting security settings: the profile %q has no security settings", profileID)
 	}
 
 	d.SetId(profileID)
 
-	d.Set("threat_intelligence_feeds", security.ThreatIntelligenceFeeds)
-	d.Set("ai_threat_detection", security.AiThreatDetection)
-	d.Set("google_safe_browsing", security.GoogleSafeBrowsing)
-	d.Set("crypto_jacking", security.Cryptojacking)
-	d.Set("dns_rebinding", security.DNSRebinding)
-	d.Set("idn_homographs", security.IdnHomographs)
-	d.Set("typo_squatting", security.Typosquatting)
-	d.Set("dga", security.Dga)
-	d.Set("nrd", security.Nrd)
-	d.Set("ddns", security.DDNS)
-	d.Set("parking", security.Parking)
-	d.Set("csam", security.Csam)
-
-	d.Set("tlds", flattenTLDs(security.Tlds))
+	values := flattenSecurity(security)
+
+	// When the resource is not authoritative, the TLDs added outside of Terraform are ignored.
+	if !d.Get("authoritative").(bool) {
+		values["tlds"] = filterDeclared(values["tlds"].([]string), stringsFromList(d.Get("tlds")))
+	}
+
+	if diags := setFlattened(d, values); diags.HasError() {
+		return diags
+	}
+
 	return nil
 }
 
 func resourceNextDNSSecurityUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
 	sec, err := buildSecurity(d)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error updating security settings: %w", err))
 	}
 	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", sec))
 
-	tlds := &nextdns.CreateSecurityTldsRequest{
-		ProfileID:    profileID,
-		SecurityTlds: sec.Tlds,
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", tlds))
+	// Switching to authoritative replaces the whole list, even if the declared TLDs did not change.
+	if d.Get("authoritative").(bool) && d.HasChanges("tlds", "authoritative") {
+		tlds := &nextdns.CreateSecurityTldsRequest{
+			ProfileID:    profileID,
+			SecurityTlds: sec.Tlds,
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", tlds))
 
-	err = client.SecurityTlds.Create(ctx, tlds)
+		err = client.SecurityTlds.Create(ctx, tlds)
+	} else if !d.Get("authoritative").(bool) && d.HasChange("tlds") {
+		previous, desired := d.GetChange("tlds")
+		err = syncSecurityTlds(ctx, meta.(*providerMeta), profileID, previous, desired)
+	}
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error updating security tlds settings: %w", err))
+		return apiErrorDiags(err, "error updating security tlds settings", listErrorTarget("", d, "tlds"))
 	}
 
-	request := &nextdns.UpdateSecurityRequest{
-		ProfileID: profileID,
-		Security:  sec,
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+	if d.HasChanges(securitySettings...) {
+		// The TLDs are written through their own endpoint.
+		sec.Tlds = nil
 
-	err = client.Security.Update(ctx, request)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error updating security settings: %w", err))
+		request := &nextdns.UpdateSecurityRequest{
+			ProfileID: profileID,
+			Security:  sec,
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+
+		err = client.Security.Update(ctx, request)
+		if err != nil {
+			return apiErrorDiags(err, "error updating security settings")
+		}
 	}
 
 	return resourceNextDNSSecurityRead(ctx, d, meta)
 }
 
 func resourceNextDNSSecurityDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
-	tlds := &nextdns.CreateSecurityTldsRequest{
-		ProfileID:    profileID,
-		SecurityTlds: []*nextdns.SecurityTlds{},
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", tlds))
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
+	var err error
+	if d.Get("authoritative").(bool) {
+		tlds := &nextdns.CreateSecurityTldsRequest{
+			ProfileID:    profileID,
+			SecurityTlds: []*nextdns.SecurityTlds{},
+		}
+		tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", tlds))
 
-	err := client.SecurityTlds.Create(ctx, tlds)
+		err = client.SecurityTlds.Create(ctx, tlds)
+	} else {
+		err = syncSecurityTlds(ctx, meta.(*providerMeta), profileID, d.Get("tlds"), nil)
+	}
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error deleting security tlds settings: %w", err))
+		if isNotFound(err) {
+			return nil
+		}
+		return apiErrorDiags(err, "error deleting security tlds settings")
 	}
 
 	sec := &nextdns.UpdateSecurityRequest{
@@ -149,7 +204,7 @@ func resourceNextDNSSecurityDelete(ctx context.Context, d *schema.ResourceData,
 
 	err = client.Security.Update(ctx, sec)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error deleting security settings: %w", err))
+		return apiErrorDiags(err, "error deleting security settings")
 	}
 
 	return resourceNextDNSSecurityRead(ctx, d, meta)
@@ -159,6 +214,7 @@ func resourceNextDNSSecurityImport(ctx context.Context, d *schema.ResourceData,
 	profileID := d.Id()
 	d.SetId(profileID)
 	d.Set("profile_id", profileID)
+	d.Set("authoritative", true)
 
 	resourceNextDNSSecurityRead(ctx, d, meta)
 
@@ -174,7 +230,26 @@ func flattenTLDs(tlds []*nextdns.SecurityTlds) []string {
 	return ids
 }
 
-func buildSecurity(d *schema.ResourceData) (*nextdns.Security, error) {
+// flattenSecurity returns the attributes of the security settings.
+func flattenSecurity(security *nextdns.Security) map[string]interface{} {
+	return map[string]interface{}{
+		"threat_intelligence_feeds": security.ThreatIntelligenceFeeds,
+		"ai_threat_detection":       security.AiThreatDetection,
+		"google_safe_browsing":      security.GoogleSafeBrowsing,
+		"crypto_jacking":            security.Cryptojacking,
+		"dns_rebinding":             security.DNSRebinding,
+		"idn_homographs":            security.IdnHomographs,
+		"typo_squatting":            security.Typosquatting,
+		"dga":                       security.Dga,
+		"nrd":                       security.Nrd,
+		"ddns":                      security.DDNS,
+		"parking":                   security.Parking,
+		"csam":                      security.Csam,
+		"tlds":                      flattenTLDs(security.Tlds),
+	}
+}
+
+func buildSecurity(d resourceData) (*nextdns.Security, error) {
 	sec := &nextdns.Security{
 		ThreatIntelligenceFeeds: d.Get("threat_intelligence_feeds").(bool),
 		AiThreatDetection:       d.Get("ai_threat_detection").(bool),
@@ -205,3 +280,18 @@ func buildSecurity(d *schema.ResourceData) (*nextdns.Security, error) {
 
 	return sec, nil
 }
+
+// syncSecurityTlds adds and removes only the declared TLDs, leaving the TLDs added outside of Terraform untouched.
+func syncSecurityTlds(ctx context.Context, m *providerMeta, profileID string, previous, desired interface{}) error {
+	request := &nextdns.ListSecurityTldsRequest{
+		ProfileID: profileID,
+	}
+	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
+
+	tlds, err := m.client.SecurityTlds.List(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	return syncIDList(ctx, m.api, profileID, securityTldsAPIPath, flattenTLDs(tlds), stringsFromList(previous), stringsFromList(desired))
+}
@@ -0,0 +1,116 @@
+package nextdns
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNextDNSSecurity_basic(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNextDNSSecurityConfig(true, `["ru", "cn"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_security.test", "nrd", "true"),
+					resource.TestCheckResourceAttr("nextdns_security.test", "tlds.#", "2"),
+				),
+			},
+			{
+				Config: testAccNextDNSSecurityConfig(false, `["ru"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_security.test", "nrd", "false"),
+					resource.TestCheckResourceAttr("nextdns_security.test", "tlds.#", "1"),
+					resource.TestCheckResourceAttr("nextdns_security.test", "tlds.0", "ru"),
+				),
+			},
+			{
+				ResourceName:      "nextdns_security.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNextDNSSecurity_nonAuthoritative(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNextDNSSecurityNonAuthoritativeConfig(`["ru"]`),
+			},
+			{
+				// A TLD added outside of Terraform must be kept when the resource is not authoritative.
+				PreConfig: func() {
+					testAccAddListEntry(s, map[string]interface{}{"id": "cn"}, "security", "tlds")
+				},
+				Config: testAccNextDNSSecurityNonAuthoritativeConfig(`["ru", "xyz"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_security.test", "tlds.#", "2"),
+					testAccCheckProfile(s, "nextdns_security.test", func(profile map[string]interface{}) error {
+						if tlds := profile["security"].(map[string]interface{})["tlds"].([]interface{}); len(tlds) != 3 {
+							return fmt.Errorf("unexpected tlds %v", tlds)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccNextDNSSecurityConfig(nrd bool, tlds string) string {
+	return testAccProfileConfig() + fmt.Sprintf(`
+resource "nextdns_security" "test" {
+  profile_id = nextdns_profile.test.id
+
+  threat_intelligence_feeds = true
+  ai_threat_detection       = true
+  google_safe_browsing      = true
+  crypto_jacking            = true
+  dns_rebinding             = true
+  idn_homographs            = true
+  typo_squatting            = true
+  dga                       = true
+  nrd                       = %[1]t
+  ddns                      = false
+  parking                   = true
+  csam                      = true
+
+  tlds = %[2]s
+}
+`, nrd, tlds)
+}
+
+func testAccNextDNSSecurityNonAuthoritativeConfig(tlds string) string {
+	return testAccProfileConfig() + fmt.Sprintf(`
+resource "nextdns_security" "test" {
+  profile_id    = nextdns_profile.test.id
+  authoritative = false
+
+  threat_intelligence_feeds = true
+  ai_threat_detection       = true
+  google_safe_browsing      = true
+  crypto_jacking            = true
+  dns_rebinding             = true
+  idn_homographs            = true
+  typo_squatting            = true
+  dga                       = true
+  nrd                       = false
+  ddns                      = false
+  parking                   = true
+  csam                      = true
+
+  tlds = %[1]s
+}
+`, tlds)
+}
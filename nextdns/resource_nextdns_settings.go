@@ -25,9 +25,12 @@ func resourceNextDNSSettings() *schema.Resource {
 }
 
 func resourceNextDNSSettingsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
 	settings, err := buildSettings(d)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error creating settings: %w", err))
@@ -42,7 +45,7 @@ func resourceNextDNSSettingsCreate(ctx context.Context, d *schema.ResourceData,
 
 	err = client.SettingsLogs.Update(ctx, logs)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error creating logs settings: %w", err))
+		return apiErrorDiags(err, "error creating logs settings")
 	}
 
 	blockPage := &nextdns.UpdateSettingsBlockPageRequest{
@@ -53,7 +56,7 @@ func resourceNextDNSSettingsCreate(ctx context.Context, d *schema.ResourceData,
 
 	err = client.SettingsBlockPage.Update(ctx, blockPage)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error creating categories settings: %w", err))
+		return apiErrorDiags(err, "error creating categories settings")
 	}
 
 	performance := &nextdns.UpdateSettingsPerformanceRequest{
@@ -64,7 +67,7 @@ func resourceNextDNSSettingsCreate(ctx context.Context, d *schema.ResourceData,
 
 	err = client.SettingsPerformance.Update(ctx, performance)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error creating categories settings: %w", err))
+		return apiErrorDiags(err, "error creating categories settings")
 	}
 
 	request := &nextdns.UpdateSettingsRequest{
@@ -75,7 +78,7 @@ func resourceNextDNSSettingsCreate(ctx context.Context, d *schema.ResourceData,
 
 	err = client.Settings.Update(ctx, request)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error creating parental control settings: %w", err))
+		return apiErrorDiags(err, "error creating parental control settings")
 	}
 
 	d.SetId(profileID)
@@ -84,46 +87,25 @@ func resourceNextDNSSettingsCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceNextDNSSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	profiles := meta.(*providerMeta).profiles
 	profileID := d.Get("profile_id").(string)
 
-	request := &nextdns.GetSettingsRequest{
-		ProfileID: profileID,
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", request))
-
-	settings, err := client.Settings.Get(ctx, request)
+	profile, err := profiles.get(ctx, profileID)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error getting settings: %w", err))
+		if isNotFound(err) {
+			return removeNotFound(ctx, d, "nextdns_settings")
+		}
+		return apiErrorDiags(err, "error getting settings")
+	}
+	settings := profile.Settings
+	if settings == nil {
+		return diag.Errorf("error getting settings: the profile %q has no settings", profileID)
 	}
 	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", settings))
 
-	logs := map[string]interface{}{}
-	logs["enabled"] = settings.Logs.Enabled
-	logs["privacy"] = []map[string]interface{}{
-		{
-			"log_clients_ip": invertPrivacySettings(settings.Logs.Drop.IP),
-			"log_domains":    invertPrivacySettings(settings.Logs.Drop.Domain),
-		},
+	if diags := setFlattened(d, flattenSettings(settings)); diags.HasError() {
+		return diags
 	}
-	logs["retention"] = convertSecondsToRetention(settings.Logs.Retention)
-	logs["location"] = settings.Logs.Location
-
-	d.Set("logs", []map[string]interface{}{logs})
-
-	blockPage := map[string]interface{}{}
-	blockPage["enabled"] = settings.BlockPage.Enabled
-
-	d.Set("block_page", []map[string]interface{}{blockPage})
-
-	performance := map[string]interface{}{}
-	performance["ecs"] = settings.Performance.Ecs
-	performance["cache_boost"] = settings.Performance.CacheBoost
-	performance["cname_flattening"] = settings.Performance.CnameFlattening
-
-	d.Set("performance", []map[string]interface{}{performance})
-
-	d.Set("web3", settings.Web3)
 
 	d.SetId(profileID)
 
@@ -131,46 +113,27 @@ func resourceNextDNSSettingsRead(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceNextDNSSettingsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
 	settings, err := buildSettings(d)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error creating settings: %w", err))
+		return diag.FromErr(fmt.Errorf("error updating settings: %w", err))
 	}
 	tflog.Debug(ctx, fmt.Sprintf("object built: %+v", settings))
 
-	logs := &nextdns.UpdateSettingsLogsRequest{
-		ProfileID:    profileID,
-		SettingsLogs: settings.Logs,
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", logs))
-
-	err = client.SettingsLogs.Update(ctx, logs)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error creating logs settings: %w", err))
-	}
-
-	blockPage := &nextdns.UpdateSettingsBlockPageRequest{
-		ProfileID:         profileID,
-		SettingsBlockPage: settings.BlockPage,
-	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", blockPage))
-
-	err = client.SettingsBlockPage.Update(ctx, blockPage)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error creating categories settings: %w", err))
+	// The nested settings are sent within a single request, leaving out the ones that did not change.
+	if !d.HasChange("logs") {
+		settings.Logs = nil
 	}
-
-	performance := &nextdns.UpdateSettingsPerformanceRequest{
-		ProfileID:           profileID,
-		SettingsPerformance: settings.Performance,
+	if !d.HasChange("block_page") {
+		settings.BlockPage = nil
 	}
-	tflog.Debug(ctx, fmt.Sprintf("request to nextdns api: %+v", performance))
-
-	err = client.SettingsPerformance.Update(ctx, performance)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error creating categories settings: %w", err))
+	if !d.HasChange("performance") {
+		settings.Performance = nil
 	}
 
 	request := &nextdns.UpdateSettingsRequest{
@@ -181,16 +144,19 @@ func resourceNextDNSSettingsUpdate(ctx context.Context, d *schema.ResourceData,
 
 	err = client.Settings.Update(ctx, request)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error creating parental control settings: %w", err))
+		return apiErrorDiags(err, "error updating settings")
 	}
 
 	return resourceNextDNSSettingsRead(ctx, d, meta)
 }
 
 func resourceNextDNSSettingsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*nextdns.Client)
+	client := meta.(*providerMeta).client
 	profileID := d.Get("profile_id").(string)
 
+	meta.(*providerMeta).profileLocks.Lock(profileID)
+	defer meta.(*providerMeta).profileLocks.Unlock(profileID)
+
 	logs := &nextdns.UpdateSettingsLogsRequest{
 		ProfileID:    profileID,
 		SettingsLogs: &nextdns.SettingsLogs{},
@@ -199,7 +165,10 @@ func resourceNextDNSSettingsDelete(ctx context.Context, d *schema.ResourceData,
 
 	err := client.SettingsLogs.Update(ctx, logs)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error deleting logs settings: %w", err))
+		if isNotFound(err) {
+			return nil
+		}
+		return apiErrorDiags(err, "error deleting logs settings")
 	}
 
 	blockPage := &nextdns.UpdateSettingsBlockPageRequest{
@@ -210,7 +179,7 @@ func resourceNextDNSSettingsDelete(ctx context.Context, d *schema.ResourceData,
 
 	err = client.SettingsBlockPage.Update(ctx, blockPage)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error deleting block page settings: %w", err))
+		return apiErrorDiags(err, "error deleting block page settings")
 	}
 
 	performance := &nextdns.UpdateSettingsPerformanceRequest{
@@ -221,7 +190,7 @@ func resourceNextDNSSettingsDelete(ctx context.Context, d *schema.ResourceData,
 
 	err = client.SettingsPerformance.Update(ctx, performance)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error deleting performance settings: %w", err))
+		return apiErrorDiags(err, "error deleting performance settings")
 	}
 
 	settings := &nextdns.UpdateSettingsRequest{
@@ -232,7 +201,7 @@ func resourceNextDNSSettingsDelete(ctx context.Context, d *schema.ResourceData,
 
 	err = client.Settings.Update(ctx, settings)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error deleting settings: %w", err))
+		return apiErrorDiags(err, "error deleting settings")
 	}
 
 	return resourceNextDNSSettingsRead(ctx, d, meta)
@@ -248,7 +217,7 @@ func resourceNextDNSSettingsImport(ctx context.Context, d *schema.ResourceData,
 	return []*schema.ResourceData{d}, nil
 }
 
-func buildSettings(d *schema.ResourceData) (*nextdns.Settings, error) {
+func buildSettings(d resourceData) (*nextdns.Settings, error) {
 	logs := &nextdns.SettingsLogs{
 		Enabled: d.Get("logs.0.enabled").(bool),
 		Drop: &nextdns.SettingsLogsDrop{
@@ -279,6 +248,40 @@ func buildSettings(d *schema.ResourceData) (*nextdns.Settings, error) {
 	return Settings, nil
 }
 
+// flattenSettings returns the attributes of the settings.
+func flattenSettings(settings *nextdns.Settings) map[string]interface{} {
+	values := make(map[string]interface{})
+
+	logs := map[string]interface{}{}
+	logs["enabled"] = settings.Logs.Enabled
+	logs["privacy"] = []map[string]interface{}{
+		{
+			"log_clients_ip": invertPrivacySettings(settings.Logs.Drop.IP),
+			"log_domains":    invertPrivacySettings(settings.Logs.Drop.Domain),
+		},
+	}
+	logs["retention"] = convertSecondsToRetention(settings.Logs.Retention)
+	logs["location"] = settings.Logs.Location
+
+	values["logs"] = []map[string]interface{}{logs}
+
+	blockPage := map[string]interface{}{}
+	blockPage["enabled"] = settings.BlockPage.Enabled
+
+	values["block_page"] = []map[string]interface{}{blockPage}
+
+	performance := map[string]interface{}{}
+	performance["ecs"] = settings.Performance.Ecs
+	performance["cache_boost"] = settings.Performance.CacheBoost
+	performance["cname_flattening"] = settings.Performance.CnameFlattening
+
+	values["performance"] = []map[string]interface{}{performance}
+
+	values["web3"] = settings.Web3
+
+	return values
+}
+
 func convertRetentionToSeconds(retention string) int {
 	switch retention {
 	case "1 hour":
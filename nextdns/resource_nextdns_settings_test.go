@@ -0,0 +1,101 @@
+package nextdns
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNextDNSSettings_basic(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNextDNSSettingsConfig("1 day", "us"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_settings.test", "logs.0.retention", "1 day"),
+					resource.TestCheckResourceAttr("nextdns_settings.test", "logs.0.location", "us"),
+					resource.TestCheckResourceAttr("nextdns_settings.test", "web3", "true"),
+				),
+			},
+			{
+				Config: testAccNextDNSSettingsConfig("1 week", "eu"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_settings.test", "logs.0.retention", "1 week"),
+					resource.TestCheckResourceAttr("nextdns_settings.test", "logs.0.location", "eu"),
+					testAccCheckProfile(s, "nextdns_settings.test", func(profile map[string]interface{}) error {
+						logs := profile["settings"].(map[string]interface{})["logs"].(map[string]interface{})
+						if logs["retention"] != float64(604800) || logs["location"] != "eu" {
+							return fmt.Errorf("unexpected logs settings %v", logs)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:      "nextdns_settings.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNextDNSSettings_partialUpdate(t *testing.T) {
+	s := testAccFakeAPI(t)
+
+	var mark int
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckProfileDestroy(s),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNextDNSSettingsConfig("1 day", "us"),
+			},
+			{
+				PreConfig: testAccMarkRequests(s, &mark),
+				Config:    testAccNextDNSSettingsConfig("1 week", "us"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nextdns_settings.test", "logs.0.retention", "1 week"),
+					testAccCheckWrites(s, "nextdns_settings.test", &mark, "PATCH settings"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNextDNSSettingsConfig(retention string, location string) string {
+	return testAccProfileConfig() + fmt.Sprintf(`
+resource "nextdns_settings" "test" {
+  profile_id = nextdns_profile.test.id
+
+  logs {
+    enabled = true
+
+    privacy {
+      log_clients_ip = true
+      log_domains    = true
+    }
+
+    retention = %[1]q
+    location  = %[2]q
+  }
+
+  block_page {
+    enabled = true
+  }
+
+  performance {
+    ecs              = true
+    cache_boost      = true
+    cname_flattening = true
+  }
+
+  web3 = true
+}
+`, retention, location)
+}
@@ -0,0 +1,31 @@
+package nextdns
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceNextDNSAllowlistDomainSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"profile_id": {
+			Description: "The profile identifier to target the resource.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"domain": {
+			Description:      "The domain to allow, normalized to lower case and punycode. A leading wildcard (e.g. *.example.com) is allowed.",
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateFunc:     validateDomain,
+			StateFunc:        normalizeDomainState,
+			DiffSuppressFunc: suppressEquivalentDomain,
+		},
+		"active": {
+			Description: "Whether the entry is active.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+	}
+}
@@ -11,21 +11,33 @@ func resourceNextDNSDenylistSchema() map[string]*schema.Schema {
 			Type:        schema.TypeString,
 			Required:    true,
 		},
+		"authoritative": listAuthoritativeSchema("nextdns_denylist_domain"),
 		"domain": {
-			Type:     schema.TypeSet,
-			Required: true,
+			Type:         schema.TypeSet,
+			Optional:     true,
+			AtLeastOneOf: []string{"domain", "source"},
+			Set:          hashDomainEntry,
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"id": {
-						Type:     schema.TypeString,
-						Required: true,
+						Description:      "The domain, normalized to lower case and punycode. A leading wildcard (e.g. *.example.com) is allowed.",
+						Type:             schema.TypeString,
+						Required:         true,
+						ValidateFunc:     validateDomain,
+						StateFunc:        normalizeDomainState,
+						DiffSuppressFunc: suppressEquivalentDomain,
 					},
 					"active": {
 						Type:     schema.TypeBool,
 						Required: true,
 					},
+					"expires_at": expiresAtSchema(),
 				},
 			},
 		},
+		"source":              domainSourceSchema("The exceptions of the adblock lists (e.g. @@||example.com^) remove the domain from the deny list."),
+		"source_domain":       sourceDomainSchema(),
+		"expired_domains":     expiredDomainsSchema(),
+		"expiry_evaluated_at": expiryEvaluatedAtSchema(),
 	}
 }
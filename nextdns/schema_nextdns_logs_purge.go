@@ -0,0 +1,30 @@
+package nextdns
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceNextDNSLogsPurgeSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"profile_id": {
+			Description: "The profile identifier to target the resource.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"triggers": {
+			Description: "Arbitrary values that purge the logs again when changed (e.g. the logs location).",
+			Type:        schema.TypeMap,
+			Optional:    true,
+			ForceNew:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"purged_at": {
+			Description: "The time the logs were last purged.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+}
@@ -1,10 +1,7 @@
 package nextdns
 
 import (
-	"regexp"
-
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNextDNSParentalControlSchema() map[string]*schema.Schema {
@@ -14,6 +11,13 @@ func resourceNextDNSParentalControlSchema() map[string]*schema.Schema {
 			Type:        schema.TypeString,
 			Required:    true,
 		},
+		"allow_unknown_ids": {
+			Description: "Whether the services and categories missing from the catalog of the provider are allowed, " +
+				"for instance the ones recently added to NextDNS. Otherwise they fail the plan, with the closest known identifier suggested.",
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
 		"block_bypass": {
 			Description: "Block bypass methods.",
 			Type:        schema.TypeBool,
@@ -26,8 +30,9 @@ func resourceNextDNSParentalControlSchema() map[string]*schema.Schema {
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"id": {
-						Type:     schema.TypeString,
-						Required: true,
+						Description: "The identifier of the category, see the nextdns_parental_control_catalog data source.",
+						Type:        schema.TypeString,
+						Required:    true,
 					},
 					"active": {
 						Type:     schema.TypeBool,
@@ -52,8 +57,9 @@ func resourceNextDNSParentalControlSchema() map[string]*schema.Schema {
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"id": {
-						Type:     schema.TypeString,
-						Required: true,
+						Description: "The identifier of the service, see the nextdns_parental_control_catalog data source.",
+						Type:        schema.TypeString,
+						Required:    true,
 					},
 					"active": {
 						Type:     schema.TypeBool,
@@ -73,44 +79,24 @@ func resourceNextDNSParentalControlSchema() map[string]*schema.Schema {
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"timezone": {
-						Type:     schema.TypeString,
-						Required: true,
-					},
-					"monday": {
-						Type:     schema.TypeList,
-						Optional: true,
-						Elem:     recreationTimeElem,
-					},
-					"tuesday": {
-						Type:     schema.TypeList,
-						Optional: true,
-						Elem:     recreationTimeElem,
-					},
-					"wednesday": {
-						Type:     schema.TypeList,
-						Optional: true,
-						Elem:     recreationTimeElem,
+						Description:  "The time zone of the windows, from the IANA database (e.g. Europe/Paris).",
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validateTimezone,
 					},
-					"thursday": {
-						Type:     schema.TypeList,
-						Optional: true,
-						Elem:     recreationTimeElem,
-					},
-					"friday": {
-						Type:     schema.TypeList,
-						Optional: true,
-						Elem:     recreationTimeElem,
-					},
-					"saturday": {
-						Type:     schema.TypeList,
-						Optional: true,
-						Elem:     recreationTimeElem,
-					},
-					"sunday": {
-						Type:     schema.TypeList,
-						Optional: true,
-						Elem:     recreationTimeElem,
+					"schedule": {
+						Description: "A window applying to several days, replacing the deprecated day blocks. Only one window per day is supported.",
+						Type:        schema.TypeList,
+						Optional:    true,
+						Elem:        recreationScheduleElem,
 					},
+					"monday":    recreationDaySchema(),
+					"tuesday":   recreationDaySchema(),
+					"wednesday": recreationDaySchema(),
+					"thursday":  recreationDaySchema(),
+					"friday":    recreationDaySchema(),
+					"saturday":  recreationDaySchema(),
+					"sunday":    recreationDaySchema(),
 				},
 			},
 		},
@@ -121,18 +107,3 @@ func resourceNextDNSParentalControlSchema() map[string]*schema.Schema {
 		},
 	}
 }
-
-var recreationTimeElem = &schema.Resource{
-	Schema: map[string]*schema.Schema{
-		"start": {
-			Type:         schema.TypeString,
-			Required:     true,
-			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^([0-1][0-9]|2[0-3]):[0-5][0-9]:00$`), "Must be in HH:MM:00 format"),
-		},
-		"end": {
-			Type:         schema.TypeString,
-			Required:     true,
-			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^([0-1][0-9]|2[0-3]):[0-5][0-9]:00$`), "Must be in HH:MM:00 format"),
-		},
-	},
-}
@@ -11,6 +11,7 @@ func resourceNextDNSPrivacySchema() map[string]*schema.Schema {
 			Type:        schema.TypeString,
 			Required:    true,
 		},
+		"authoritative": authoritativeSchema(),
 		"allow_affiliate": {
 			Description: "Allow affiliate & tracking links.",
 			Type:        schema.TypeBool,
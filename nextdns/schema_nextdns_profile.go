@@ -5,6 +5,12 @@ import (
 )
 
 func resourceNextDNSProfileSchema() map[string]*schema.Schema {
+	rewrite := resourceNextDNSRewriteSchema()["rewrite"]
+	rewrite.Description = "Rewrites of the profile, see the nextdns_rewrite resource. Like it, the block deletes the rewrites " +
+		"it does not declare, so it cannot be combined with nextdns_rewrite_record resources on the profile."
+	rewrite.Required = false
+	rewrite.Optional = true
+
 	return map[string]*schema.Schema{
 		"profile_id": {
 			Description: "The profile identifier to target the resource.",
@@ -16,5 +22,81 @@ func resourceNextDNSProfileSchema() map[string]*schema.Schema {
 			Type:        schema.TypeString,
 			Required:    true,
 		},
+		"security": {
+			Description: "Security settings of the profile, see the nextdns_security resource.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: profileNestedSchema(resourceNextDNSSecuritySchema()),
+			},
+		},
+		"privacy": {
+			Description: "Privacy settings of the profile, see the nextdns_privacy resource.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: profileNestedSchema(resourceNextDNSPrivacySchema()),
+			},
+		},
+		"parental_control": {
+			Description: "Parental control settings of the profile, see the nextdns_parental_control resource.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: profileNestedSchema(resourceNextDNSParentalControlSchema()),
+			},
+		},
+		"denylist": {
+			Description: "Deny list of the profile, see the nextdns_denylist resource.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: profileNestedSchema(resourceNextDNSDenylistSchema()),
+			},
+		},
+		"allowlist": {
+			Description: "Allow list of the profile, see the nextdns_allowlist resource.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: profileNestedSchema(resourceNextDNSAllowlistSchema()),
+			},
+		},
+		"settings": {
+			Description: "Settings of the profile, see the nextdns_settings resource.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: profileNestedSchema(resourceNextDNSSettingsSchema()),
+			},
+		},
+		"rewrite": rewrite,
+	}
+}
+
+// profileNestedSchema returns the schema of a resource to be nested in the nextdns_profile resource,
+// without the arguments that only make sense for the standalone resource.
+func profileNestedSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	delete(s, "profile_id")
+	delete(s, "authoritative")
+
+	// The sources and the expiry times are evaluated when planning, which is only supported by the attributes of the resource itself.
+	if _, ok := s["source"]; ok {
+		delete(s, "source")
+		delete(s, "source_domain")
+		delete(s, "expired_domains")
+		delete(s, "expiry_evaluated_at")
+		delete(s["domain"].Elem.(*schema.Resource).Schema, "expires_at")
+		s["domain"].Optional = false
+		s["domain"].Required = true
+		s["domain"].AtLeastOneOf = nil
 	}
+
+	return s
 }
@@ -12,6 +12,8 @@ func resourceNextDNSRewriteSchema() map[string]*schema.Schema {
 			Required:    true,
 		},
 		"rewrite": {
+			Description: "The rewrites of the profile. The resource owns every rewrite of the profile and deletes the ones " +
+				"it does not declare, including the ones of nextdns_rewrite_record resources, so both cannot be used on a same profile.",
 			Type:     schema.TypeSet,
 			Required: true,
 			Elem: &schema.Resource{
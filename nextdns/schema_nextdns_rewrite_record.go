@@ -0,0 +1,37 @@
+package nextdns
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceNextDNSRewriteRecordSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"profile_id": {
+			Description: "The profile identifier to target the resource. The rewrites of the profile must not be managed by " +
+				"nextdns_rewrite or the rewrite block of nextdns_profile, which delete the rewrites they do not declare.",
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"domain": {
+			Description: "The domain to rewrite.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"address": {
+			Description: "The IP address or domain name the domain is rewritten to.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"record_id": {
+			Description: "The identifier of the rewrite in the NextDNS API.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"type": {
+			Description: "The type of DNS record of the rewrite, as detected by the NextDNS API.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+}
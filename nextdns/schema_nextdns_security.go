@@ -11,6 +11,7 @@ func resourceNextDNSSecuritySchema() map[string]*schema.Schema {
 			Type:        schema.TypeString,
 			Required:    true,
 		},
+		"authoritative": authoritativeSchema(),
 		"threat_intelligence_feeds": {
 			Description: "Threat intelligence feeds.",
 			Type:        schema.TypeBool,
@@ -1,5 +1,17 @@
 package nextdns
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/amalucelli/nextdns-go/nextdns"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
 const (
 	// NextDNSDomain is the domain name of the NextDNS service.
 	NextDNSDomain = "nextdns.io"
@@ -14,3 +26,160 @@ func DNSOverHTTPSAddress(profileID string) string {
 func DNSOverTLSAddress(profileID string) string {
 	return profileID + ".dns." + NextDNSDomain
 }
+
+// isNotFound reports whether the error returned by the NextDNS API means that the object does not exist.
+func isNotFound(err error) bool {
+	var apiErr *nextdns.Error
+	return errors.As(err, &apiErr) && apiErr.Type == nextdns.ErrorTypeNotFound
+}
+
+// removeNotFound removes a resource whose profile no longer exists in NextDNS from the state,
+// warning the user so Terraform plans its recreation.
+func removeNotFound(ctx context.Context, d *schema.ResourceData, resource string) diag.Diagnostics {
+	profileID := d.Get("profile_id").(string)
+
+	return removeFromState(ctx, d,
+		fmt.Sprintf("%s not found", resource),
+		fmt.Sprintf("The profile %q no longer exists in NextDNS, so %s was removed from the state and will be recreated on the next apply.", profileID, resource),
+	)
+}
+
+// removeFromState removes a resource from the state, warning the user with the given summary and detail.
+func removeFromState(ctx context.Context, d *schema.ResourceData, summary string, detail string) diag.Diagnostics {
+	tflog.Warn(ctx, fmt.Sprintf("%s, removing %q from state", summary, d.Id()))
+
+	d.SetId("")
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  summary,
+			Detail:   detail,
+		},
+	}
+}
+
+// configuredValue returns the value of an argument, and whether the argument is in the configuration.
+// Unlike GetOk, an empty list or set in the configuration is reported as set.
+func configuredValue(d *schema.ResourceData, key string) (interface{}, bool) {
+	config := d.GetRawConfig()
+	if config.IsNull() {
+		// The raw configuration is not available outside of Terraform (e.g. in the unit tests).
+		return d.GetOk(key)
+	}
+
+	if config.GetAttr(key).IsNull() {
+		return nil, false
+	}
+
+	return d.Get(key), true
+}
+
+// configuredStrings returns the strings of a set argument, and whether the argument is in the configuration.
+// Unlike GetOk, an empty set in the configuration is reported as set.
+func configuredStrings(d *schema.ResourceData, key string) ([]string, bool) {
+	v, ok := configuredValue(d, key)
+	return stringsFromSet(v), ok
+}
+
+// revertChanges sets the attributes back to their prior values, so the state does not record the changes
+// which were not written when an update fails part-way.
+func revertChanges(d *schema.ResourceData, keys ...string) {
+	for _, key := range keys {
+		old, _ := d.GetChange(key)
+		d.Set(key, old)
+	}
+}
+
+// importReadError returns the error of the read of an imported resource, when its diagnostics hold an error
+// or the resource was removed from the state as it does not exist.
+func importReadError(d *schema.ResourceData, diags diag.Diagnostics) error {
+	if !diags.HasError() && len(d.Id()) > 0 {
+		return nil
+	}
+
+	var details []string
+	for _, diagnostic := range diags {
+		details = append(details, strings.TrimSuffix(diagnostic.Summary+": "+diagnostic.Detail, ": "))
+	}
+	if len(details) == 0 {
+		details = append(details, "not found")
+	}
+
+	// nolint:goerr113
+	return errors.New(strings.Join(details, "; "))
+}
+
+// parseTwoPartID parses an ID in the <profile_id>/<id> format.
+func parseTwoPartID(id string, name string) (string, string, error) {
+	profileID, part, ok := strings.Cut(id, "/")
+	if !ok || len(profileID) == 0 || len(part) == 0 {
+		// nolint:goerr113
+		return "", "", fmt.Errorf("unexpected format of ID %q, expected <profile_id>/<%s>", id, name)
+	}
+
+	return profileID, part, nil
+}
+
+// resourceData is the subset of *schema.ResourceData used to build the objects sent to the NextDNS API,
+// so they can be built either from a resource or from a block nested in the nextdns_profile resource.
+type resourceData interface {
+	Get(key string) interface{}
+	GetOk(key string) (interface{}, bool)
+	HasChanges(keys ...string) bool
+}
+
+// nestedData exposes a block nested in a resource as if it was the resource itself.
+type nestedData struct {
+	d      *schema.ResourceData
+	block  string
+	prefix string
+}
+
+var _ resourceData = &nestedData{}
+
+// newNestedData returns the block of a single element list nested in the resource.
+func newNestedData(d *schema.ResourceData, block string) *nestedData {
+	return &nestedData{
+		d:      d,
+		block:  block,
+		prefix: block + ".0.",
+	}
+}
+
+// Get returns the value of the key within the nested block.
+func (n *nestedData) Get(key string) interface{} {
+	return n.d.Get(n.prefix + key)
+}
+
+// GetOk returns the value of the key within the nested block and if it is set.
+func (n *nestedData) GetOk(key string) (interface{}, bool) {
+	return n.d.GetOk(n.prefix + key)
+}
+
+// HasChanges reports whether any of the keys within the nested block changed.
+// Every key is considered changed when the block was just added to the configuration.
+func (n *nestedData) HasChanges(keys ...string) bool {
+	if previous, _ := n.d.GetChange(n.block); len(previous.([]interface{})) == 0 {
+		return true
+	}
+
+	for _, key := range keys {
+		if n.d.HasChange(n.prefix + key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setFlattened sets every flattened attribute in the resource data.
+func setFlattened(d *schema.ResourceData, values map[string]interface{}) diag.Diagnostics {
+	for k, v := range values {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}